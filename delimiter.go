@@ -0,0 +1,19 @@
+package main
+
+import "github.com/kaiquegarcia/gostudy/v2/stream"
+
+// parseDelimiter reads the delimiter of every CSV file, by name or by the delimiter itself.
+func parseDelimiter(name string) (byte, error) {
+	switch name {
+	case "auto", "":
+		return stream.AutoDelimiter, nil
+	case "tab", `\t`:
+		return '\t', nil
+	}
+
+	if len(name) != 1 {
+		return 0, stream.ErrUnsupportedDelimiter
+	}
+
+	return name[0], stream.CheckDelimiter(name[0])
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"errors"
+	"time"
+
+	"github.com/kaiquegarcia/gostudy/v2/logging"
+	"github.com/kaiquegarcia/gostudy/v2/planner"
+	"github.com/kaiquegarcia/gostudy/v2/utils"
+)
+
+func runEstimate(
+	logger logging.Logger,
+	hourGrade *planner.HourGrade,
+	disciplines []*planner.Discipline,
+	records *inputRecords,
+	startDate time.Time,
+	endDate time.Time,
+	optFilenames utils.OptionalFilenames,
+	inputOptions []planner.InputOption,
+	makerOptions ...planner.MakerOption,
+) {
+	logger.Debug("estimating the daily limits to finish until %s", endDate.Format(planner.LayoutDateOnly))
+	estimate, err := planner.NewEstimate(hourGrade, disciplines, startDate, endDate)
+	if err != nil {
+		logger.Error(err, "could not estimate the daily limits")
+		return
+	}
+
+	// the simulations would flood the output with debug messages
+	quietLogger := logging.NewLogger(logging.DefaultPrinter, logging.LevelError)
+	var (
+		report     *planner.Report
+		bestLimits []time.Duration
+	)
+	for iteration := 1; iteration <= planner.MaxEstimateIterations; iteration++ {
+		logger.Debug("simulating the plan with the estimated daily limits, iteration %d", iteration)
+		current, err := simulateEstimate(quietLogger, hourGrade, estimate.ApplyTo(records.Disciplines), records.Contents, startDate, inputOptions, makerOptions...)
+		if err != nil {
+			logger.Error(err, "could not simulate planner")
+			return
+		}
+
+		if report == nil || estimate.Improves(report, current) {
+			report = current
+			bestLimits = estimate.DailyLimits()
+		}
+
+		if estimate.Fits(current) || !estimate.Increase(current) {
+			break
+		}
+	}
+
+	// higher daily limits that didn't make the plan end sooner are only noise
+	estimate.RestoreDailyLimits(bestLimits)
+	estimate.Print(logging.DefaultPrinter)
+	if !estimate.Fits(report) {
+		logger.Warn(
+			"even with the proposed daily limits the plan ends at %s, consider adding more time intervals to the hour grade",
+			report.EndDate.Format(planner.LayoutDateOnly),
+		)
+	}
+
+	err = utils.WriteCSV(optFilenames.ProposedDisciplines, estimate.ApplyTo(records.Disciplines))
+	if err != nil {
+		logger.Error(err, "could not write '%s'", optFilenames.ProposedDisciplines)
+		return
+	}
+
+	logger.Info("proposed disciplines list written to '%s'", optFilenames.ProposedDisciplines)
+}
+
+func simulateEstimate(
+	logger logging.Logger,
+	hourGrade *planner.HourGrade,
+	disciplineRecords [][]string,
+	contents map[string][][]string,
+	startDate time.Time,
+	inputOptions []planner.InputOption,
+	makerOptions ...planner.MakerOption,
+) (*planner.Report, error) {
+	disciplines, err := planner.NewDisciplineFromRowsWithContents(disciplineRecords, contents, inputOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	maker := planner.NewDryRunMaker(logger, hourGrade, disciplines, startDate, makerOptions...)
+	err = maker.Mount()
+	closeErr := maker.Close()
+	if err != nil && !errors.Is(err, planner.ErrDeadlineMissed) {
+		return nil, err
+	}
+
+	if closeErr != nil {
+		return nil, closeErr
+	}
+
+	return maker.Report(), nil
+}
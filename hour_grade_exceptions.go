@@ -0,0 +1,73 @@
+package main
+
+import (
+	"os"
+
+	"github.com/kaiquegarcia/gostudy/v2/logging"
+	"github.com/kaiquegarcia/gostudy/v2/planner"
+	"github.com/kaiquegarcia/gostudy/v2/utils"
+)
+
+func loadBlackouts(logger logging.Logger, hourGrade *planner.HourGrade, optFilenames utils.OptionalFilenames, delimiter byte) error {
+	if utils.FileExists(optFilenames.Blackouts) {
+		logger.Debug("reading '%s'", optFilenames.Blackouts)
+		records, err := utils.ReadCSV(optFilenames.Blackouts, delimiter)
+		if err != nil {
+			logger.Error(err, "could not read '%s'", optFilenames.Blackouts)
+			return err
+		}
+
+		blackouts, err := planner.NewBlackoutsFromRows(records)
+		if err != nil {
+			logger.Error(err, "could not extract blackouts from table records")
+			return err
+		}
+
+		logger.Debug("%d blackouts extracted successfully", len(blackouts))
+		hourGrade.AddBlackouts(blackouts...)
+	}
+
+	if utils.FileExists(optFilenames.BlackoutsCalendar) {
+		logger.Debug("reading '%s'", optFilenames.BlackoutsCalendar)
+		file, err := os.Open(optFilenames.BlackoutsCalendar)
+		if err != nil {
+			logger.Error(err, "could not read '%s'", optFilenames.BlackoutsCalendar)
+			return err
+		}
+		defer file.Close()
+
+		blackouts, err := planner.NewBlackoutsFromICS(file)
+		if err != nil {
+			logger.Error(err, "could not extract blackouts from calendar events")
+			return err
+		}
+
+		logger.Debug("%d blackouts extracted successfully from calendar", len(blackouts))
+		hourGrade.AddBlackouts(blackouts...)
+	}
+
+	return nil
+}
+
+func loadOverrides(logger logging.Logger, hourGrade *planner.HourGrade, optFilenames utils.OptionalFilenames, delimiter byte) error {
+	if !utils.FileExists(optFilenames.HourGradeOverrides) {
+		return nil
+	}
+
+	logger.Debug("reading '%s'", optFilenames.HourGradeOverrides)
+	records, err := utils.ReadCSV(optFilenames.HourGradeOverrides, delimiter)
+	if err != nil {
+		logger.Error(err, "could not read '%s'", optFilenames.HourGradeOverrides)
+		return err
+	}
+
+	overrides, err := planner.NewHourGradeOverridesFromRows(records)
+	if err != nil {
+		logger.Error(err, "could not extract hour grade overrides from table records")
+		return err
+	}
+
+	logger.Debug("%d hour grade overrides extracted successfully", len(overrides))
+	hourGrade.AddOverrides(overrides...)
+	return nil
+}
@@ -1,14 +1,24 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"os"
 	"time"
 
 	"github.com/kaiquegarcia/gostudy/v2/logging"
 	"github.com/kaiquegarcia/gostudy/v2/planner"
+	"github.com/kaiquegarcia/gostudy/v2/stream"
 	"github.com/kaiquegarcia/gostudy/v2/utils"
 )
 
+const (
+	commandPlan     = "plan"
+	commandCheck    = "check"
+	commandEstimate = "estimate"
+	commandReplan   = "replan"
+)
+
 func main() {
 	// dependencies
 	logger := logging.NewLogger(
@@ -24,47 +34,172 @@ func main() {
 		Output:          "planner.csv",
 	}
 
+	optFilenames := utils.OptionalFilenames{
+		Blackouts:           "blackouts.csv",
+		BlackoutsCalendar:   "blackouts.ics",
+		HourGradeOverrides:  "hour_grade_overrides.csv",
+		ProposedDisciplines: "disciplines.proposed.csv",
+		Progress:            "progress.csv",
+	}
+
+	command := commandPlan
+	args := os.Args[1:]
+	if len(args) > 0 && (args[0] == commandPlan || args[0] == commandCheck || args[0] == commandEstimate || args[0] == commandReplan) {
+		command = args[0]
+		args = args[1:]
+	}
+
+	strategyName := flag.String(
+		"strategy",
+		planner.StrategyRoundRobin,
+		"how to choose the next discipline: round-robin, weighted, least-recent, most-remaining or deadline",
+	)
+	breaksValue := flag.String(
+		"breaks",
+		"",
+		"break policy as focus/break or focus/break/long break/every, like 25m/5m/15m/4 (disabled by default)",
+	)
+	packingWindow := flag.Int(
+		"pack",
+		0,
+		"fill the end of the intervals with the content that fits best, looking ahead up to N contents of the reorderable disciplines (disabled by default)",
+	)
+	outputFormat := flag.String(
+		"format",
+		planner.OutputFormatCSV,
+		"format of the plan file: csv, json, ics, md or html",
+	)
+	projectFilename := flag.String(
+		"project",
+		"",
+		"project file (.yaml, .yml or .json) to use instead of the CSV files, gostudy.yaml/.yml/.json are used if found",
+	)
+	shortDurationMode := flag.String(
+		"short-durations",
+		string(planner.DurationModeHoursMinutes),
+		"how to read the durations with two pieces, like 45:10: hh:mm or mm:ss",
+	)
+	delimiterName := flag.String(
+		"delimiter",
+		"auto",
+		"delimiter of the CSV files: auto (detected from the header), ',', ';', tab or '|'",
+	)
+	flag.CommandLine.Parse(args)
+
 	// run
+	delimiter, err := parseDelimiter(*delimiterName)
+	if err != nil {
+		logger.Error(err, "could not use delimiter '%s'", *delimiterName)
+		return
+	}
+
+	durationMode, err := planner.ParseDurationMode(*shortDurationMode)
+	if err != nil {
+		logger.Error(err, "could not use short durations mode '%s'", *shortDurationMode)
+		return
+	}
+
+	inputOptions := []planner.InputOption{
+		planner.WithShortDurationMode(durationMode),
+		planner.WithCSVOptions(stream.WithDelimiter(delimiter)),
+	}
+
+	strategyFactory, err := planner.NewSelectionStrategyFactory(*strategyName)
+	if err != nil {
+		logger.Error(err, "could not use strategy '%s'", *strategyName)
+		return
+	}
+
+	dateArgs := flag.Args()
+	makerOptions := []planner.MakerOption{
+		planner.WithSelectionStrategyFactory(strategyFactory),
+	}
+
+	if *breaksValue != "" {
+		breakPolicy, err := planner.ParseBreakPolicy(*breaksValue, inputOptions...)
+		if err != nil {
+			logger.Error(err, "could not use break policy '%s'", *breaksValue)
+			return
+		}
+
+		makerOptions = append(makerOptions, planner.WithBreakPolicy(breakPolicy))
+	}
+
+	if *packingWindow > 0 {
+		makerOptions = append(makerOptions, planner.WithPacking(*packingWindow))
+	}
+
+	var endDate time.Time
+	if command == commandEstimate {
+		if len(dateArgs) == 0 {
+			logger.Error(planner.ErrInvalidDateRange, "the estimate command requires the end date as the first argument")
+			return
+		}
+
+		logger.Debug("prepare to parse end date from the first argument")
+		endDate, err = time.Parse(planner.LayoutDateOnly, dateArgs[0])
+		if err != nil {
+			logger.Error(err, "could not parse the first argument")
+			return
+		}
+
+		dateArgs = dateArgs[1:]
+	}
+
 	var startDate time.Time
-	if len(os.Args) > 1 {
-		logger.Debug("prepare to parse date from os.Args[1]")
-		d, err := time.Parse(planner.LayoutDateOnly, os.Args[1])
+	if len(dateArgs) > 0 {
+		logger.Debug("prepare to parse start date from the arguments")
+		d, err := time.Parse(planner.LayoutDateOnly, dateArgs[0])
 		if err != nil {
-			logger.Error(err, "could not parse os.Args[1]")
+			logger.Error(err, "could not parse the start date argument")
 			return
 		}
 
 		logger.Debug("date parsed successfully")
 		startDate = d
+	} else if command == commandReplan {
+		logger.Debug("using today as startDate to replan")
+		startDate = time.Now()
 	} else {
 		logger.Debug("using now + 6days as startDate")
 		startDate = time.Now().AddDate(0, 0, 6)
 	}
 
-	logger.Debug("reading '%s'", reqFilenames.HourGrade)
-	hourGradeRecords, err := utils.ReadCSV(reqFilenames.HourGrade)
+	records, err := loadRecords(logger, reqFilenames, *projectFilename, delimiter)
 	if err != nil {
-		logger.Error(err, "could not read '%s'", reqFilenames.HourGrade)
 		return
 	}
 
-	logger.Debug("'%s' readed successfuly, preparing to extract information from records", reqFilenames.HourGrade)
-	hourGrade, err := planner.NewHourGradeFromRow(hourGradeRecords)
+	logger.Debug("records readed successfuly, preparing to extract the hour grade")
+	hourGrade, err := planner.NewHourGradeFromRow(records.HourGrade)
 	if err != nil {
 		logger.Error(err, "could not extract hour grade from table records")
 		return
 	}
 
-	logger.Debug("hour grade extracted successfully")
-	logger.Debug("reading '%s'", reqFilenames.DisciplinesList)
-	disciplineRecords, err := utils.ReadCSV(reqFilenames.DisciplinesList)
+	logger.Debug("hour grade extracted successfully, checking for blackout dates")
+	err = loadBlackouts(logger, hourGrade, optFilenames, delimiter)
+	if err != nil {
+		return
+	}
+
+	logger.Debug("checking for hour grade overrides")
+	err = loadOverrides(logger, hourGrade, optFilenames, delimiter)
 	if err != nil {
-		logger.Error(err, "could not read '%s'", reqFilenames.DisciplinesList)
 		return
 	}
 
-	logger.Debug("'%s' readed successfuly, preparing to extract information from records", reqFilenames.DisciplinesList)
-	disciplines, err := planner.NewDisciplineFromRows(disciplineRecords)
+	if command == commandReplan {
+		progress, err := loadProgress(logger, optFilenames.Progress, delimiter, inputOptions...)
+		if err != nil {
+			return
+		}
+
+		makerOptions = append(makerOptions, planner.WithProgress(progress))
+	}
+
+	logger.Debug("preparing to extract the disciplines list from records")
+	disciplines, err := planner.NewDisciplineFromRowsWithContents(records.Disciplines, records.Contents, inputOptions...)
 	if err != nil {
 		logger.Error(err, "could not extract disciplines list from table records")
 		return
@@ -82,20 +217,80 @@ func main() {
 	}()
 
 	logger.Debug("disciplines list data extracted successfuly, initializing planner maker")
-	maker, err := planner.NewMaker(logger, hourGrade, disciplines, startDate, reqFilenames.Output)
+	if command == commandEstimate {
+		makerReady = true
+		defer func() {
+			for _, d := range disciplines {
+				d.Close()
+			}
+		}()
+
+		runEstimate(logger, hourGrade, disciplines, records, startDate, endDate, optFilenames, inputOptions, makerOptions...)
+		return
+	}
+
+	if command == commandCheck {
+		logger.Debug("check mode, simulating the plan without writing '%s'", reqFilenames.Output)
+		maker := planner.NewDryRunMaker(logger, hourGrade, disciplines, startDate, makerOptions...)
+		makerReady = true
+
+		err = maker.Mount()
+		closeErr := maker.Close()
+		if err != nil && !errors.Is(err, planner.ErrDeadlineMissed) {
+			logger.Error(err, "could not simulate planner")
+			return
+		}
+
+		if closeErr != nil {
+			logger.Error(closeErr, "could not close the simulated planner")
+			return
+		}
+
+		maker.Report().Print(logging.DefaultPrinter)
+		return
+	}
+
+	sink, outputFilename, err := openOutputSink(reqFilenames.Output, *outputFormat)
 	if err != nil {
-		logger.Error(err, "could not initialize planner maker")
+		logger.Error(err, "could not create '%s'", outputFilename)
 		return
 	}
-	defer maker.Close()
+
+	logger.Debug("writing the plan to '%s'", outputFilename)
+	maker := planner.NewMakerWithSink(
+		logger,
+		hourGrade,
+		disciplines,
+		startDate,
+		sink,
+		makerOptions...,
+	)
 	makerReady = true
 
 	logger.Debug("preparing to mount planner")
 	err = maker.Mount()
+	// the sinks write their ending on close, so it must succeed even if the mount didn't
+	closeErr := maker.Close()
+	if errors.Is(err, planner.ErrDeadlineMissed) {
+		for _, miss := range maker.DeadlineMisses() {
+			logger.Warn(
+				"discipline '%s' is missing %s of study to finish before its deadline (%s)",
+				miss.Discipline.Name,
+				miss.MissingHours,
+				miss.Deadline.Format(planner.LayoutDateOnly),
+			)
+		}
+	}
+
 	if err != nil {
 		logger.Error(err, "could not mount planner")
 		return
 	}
 
+	if closeErr != nil {
+		logger.Error(closeErr, "could not finish writing '%s'", outputFilename)
+		return
+	}
+
 	logger.Debug("planner mounted successfully")
 }
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kaiquegarcia/gostudy/v2/planner"
+)
+
+// openOutputSink creates the output file with the extension of the format,
+// like planner.json for the json format. Unknown formats don't touch any file.
+func openOutputSink(filename string, format string) (planner.OutputSink, string, error) {
+	if !planner.IsOutputFormat(format) {
+		return nil, filename, fmt.Errorf("%w: %q", planner.ErrUnknownOutputFormat, format)
+	}
+
+	filename = strings.TrimSuffix(filename, filepath.Ext(filename)) + "." + format
+	file, err := os.Create(filename)
+	if err != nil {
+		return nil, filename, err
+	}
+
+	sink, err := planner.NewOutputSink(format, file)
+	if err != nil {
+		file.Close()
+		os.Remove(filename)
+		return nil, filename, err
+	}
+
+	return sink, filename, nil
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kaiquegarcia/gostudy/v2/planner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_openOutputSink(t *testing.T) {
+	t.Run("should create the file with the extension of the format", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+
+		// Act
+		sink, filename, err := openOutputSink(filepath.Join(dir, "planner.csv"), planner.OutputFormatJSON)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Nil(t, sink.Close(), "err from Close should be nil")
+		assert.Equal(t, filepath.Join(dir, "planner.json"), filename)
+		assert.FileExists(t, filename)
+	})
+
+	t.Run("should not touch any file on unknown formats", func(t *testing.T) {
+		for _, format := range []string{"txt", "../csv", ""} {
+			// Arrange
+			dir := t.TempDir()
+			existing := filepath.Join(dir, "planner.txt")
+			require.NoError(t, os.WriteFile(existing, []byte("notes"), 0o644))
+
+			// Act
+			_, _, err := openOutputSink(filepath.Join(dir, "planner.csv"), format)
+
+			// Assert
+			assert.ErrorIs(t, err, planner.ErrUnknownOutputFormat, "'%s' should be refused", format)
+			data, readErr := os.ReadFile(existing)
+			assert.Nil(t, readErr, "the existing file should be kept")
+			assert.Equal(t, "notes", string(data), "the existing file should be intact")
+			entries, _ := os.ReadDir(dir)
+			assert.Len(t, entries, 1, "no file should be created")
+		}
+	})
+}
@@ -0,0 +1,65 @@
+package planner
+
+import (
+	"time"
+)
+
+type Blackout struct {
+	Start  time.Time
+	End    time.Time
+	Reason string
+}
+
+type SkippedDate struct {
+	Date   time.Time
+	Reason string
+}
+
+func (b *Blackout) Contains(date time.Time) bool {
+	date = dateOnly(date)
+	return !date.Before(b.Start) && !date.After(b.End)
+}
+
+func NewBlackoutsFromRows(rows [][]string) ([]*Blackout, error) {
+	blackouts := make([]*Blackout, 0)
+	for line := 1; line < len(rows); line++ {
+		columns := rows[line]
+		if len(columns) == 0 || columns[0] == "" {
+			break
+		}
+		// Start Date, [End Date], [Reason]
+		if len(columns) > 3 {
+			return nil, ErrUnexpectedColumnsLength
+		}
+
+		start, err := time.Parse(LayoutDateOnly, columns[0])
+		if err != nil {
+			return nil, err
+		}
+
+		blackout := &Blackout{Start: start, End: start}
+		if len(columns) > 1 && columns[1] != "" {
+			blackout.End, err = time.Parse(LayoutDateOnly, columns[1])
+			if err != nil {
+				return nil, err
+			}
+
+			if blackout.End.Before(blackout.Start) {
+				return nil, ErrInvalidDateRange
+			}
+		}
+
+		if len(columns) > 2 {
+			blackout.Reason = columns[2]
+		}
+
+		blackouts = append(blackouts, blackout)
+	}
+
+	return blackouts, nil
+}
+
+// dateOnly drops the clock and the timezone, keeping the calendar date as it was.
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,143 @@
+package planner
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	layoutICSDate     = "20060102"
+	layoutICSDateTime = "20060102T150405"
+)
+
+// NewBlackoutsFromICS reads every VEVENT of an iCalendar file as a blackout of
+// the whole days it touches, using its SUMMARY as the reason. The UTC times are
+// moved to the local clock first. Recurring events are refused, as only their
+// first date would be blocked.
+func NewBlackoutsFromICS(r io.Reader) ([]*Blackout, error) {
+	lines, err := unfoldICSLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	blackouts := make([]*Blackout, 0)
+	var (
+		current   *Blackout
+		hasEnd    bool
+		recurring bool
+		// the components inside the event, like alarms, have their own properties
+		depth int
+	)
+	for _, line := range lines {
+		name, value := splitICSLine(line)
+		switch {
+		case current != nil && name == "BEGIN":
+			depth++
+		case current != nil && name == "END" && depth > 0:
+			depth--
+		case name == "BEGIN" && value == "VEVENT":
+			current = &Blackout{}
+			hasEnd = false
+			recurring = false
+		case name == "END" && value == "VEVENT" && current != nil:
+			if recurring {
+				return nil, fmt.Errorf("%w: %q", ErrRecurringCalendarEvent, current.Reason)
+			}
+
+			if current.Start.IsZero() {
+				return nil, ErrInvalidCalendarEvent
+			}
+
+			if !hasEnd || current.End.Before(current.Start) {
+				current.End = current.Start
+			}
+
+			blackouts = append(blackouts, current)
+			current = nil
+		case current == nil || depth > 0:
+			continue
+		case name == "DTSTART":
+			start, _, err := parseICSDate(value)
+			if err != nil {
+				return nil, err
+			}
+
+			current.Start = dateOnly(start)
+		case name == "DTEND":
+			end, isDateOnly, err := parseICSDate(value)
+			if err != nil {
+				return nil, err
+			}
+
+			// the end of all-day events is exclusive, as is an end at midnight
+			if isDateOnly || end.Equal(time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, end.Location())) {
+				end = end.AddDate(0, 0, -1)
+			}
+
+			current.End = dateOnly(end)
+			hasEnd = true
+		case name == "SUMMARY":
+			current.Reason = unescapeICSText(value)
+		case name == "RRULE" || name == "RDATE" || name == "EXDATE":
+			recurring = true
+		}
+	}
+
+	return blackouts, nil
+}
+
+func unfoldICSLines(r io.Reader) ([]string, error) {
+	lines := make([]string, 0)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines, scanner.Err()
+}
+
+// splitICSLine ignores the parameters, like in "DTSTART;VALUE=DATE:20240101"
+func splitICSLine(line string) (name string, value string) {
+	separator := strings.Index(line, ":")
+	if separator < 0 {
+		return strings.ToUpper(line), ""
+	}
+
+	name, value = line[:separator], line[separator+1:]
+	if index := strings.Index(name, ";"); index >= 0 {
+		name = name[:index]
+	}
+
+	return strings.ToUpper(name), strings.TrimSpace(value)
+}
+
+// parseICSDate reads dates and local times, converting the UTC ones (ended by Z)
+// to the local clock, like the hour grade.
+func parseICSDate(value string) (time.Time, bool, error) {
+	if len(value) == len(layoutICSDate) {
+		date, err := time.Parse(layoutICSDate, value)
+		return date, true, err
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		datetime, err := time.Parse(layoutICSDateTime, strings.TrimSuffix(value, "Z"))
+		return datetime.In(time.Local), false, err
+	}
+
+	datetime, err := time.Parse(layoutICSDateTime, value)
+	return datetime, false, err
+}
+
+func unescapeICSText(value string) string {
+	replacer := strings.NewReplacer(`\,`, ",", `\;`, ";", `\n`, " ", `\N`, " ", `\\`, `\`)
+	return replacer.Replace(value)
+}
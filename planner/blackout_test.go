@@ -0,0 +1,156 @@
+package planner_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kaiquegarcia/gostudy/v2/planner"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Blackout(t *testing.T) {
+	t.Run("NewBlackoutsFromRows should accept single dates and ranges", func(t *testing.T) {
+		// Arrange
+		rows := [][]string{
+			{"Start Date", "End Date", "Reason"},
+			{"2024-12-25", "", "Christmas"},
+			{"2024-07-01", "2024-07-15", "Trip"},
+		}
+
+		// Act
+		blackouts, err := planner.NewBlackoutsFromRows(rows)
+
+		// Assert
+		if !assert.Nil(t, err, "err from NewBlackoutsFromRows should be nil") || !assert.Len(t, blackouts, 2, "should have 2 blackouts") {
+			t.FailNow()
+		}
+		assert.Equal(t, "2024-12-25", blackouts[0].End.Format(planner.LayoutDateOnly), "single date should end on itself")
+		assert.Equal(t, "Trip", blackouts[1].Reason, "reason should be 'Trip'")
+		assert.True(t, blackouts[1].Contains(time.Date(2024, 7, 15, 20, 0, 0, 0, time.Local)), "the last day of the range should be included")
+		assert.False(t, blackouts[1].Contains(time.Date(2024, 7, 16, 0, 0, 0, 0, time.UTC)), "the day after the range should not be included")
+	})
+
+	t.Run("NewBlackoutsFromICS should read all-day and timed events", func(t *testing.T) {
+		// Arrange
+		ics := strings.Join([]string{
+			"BEGIN:VCALENDAR",
+			"BEGIN:VEVENT",
+			"DTSTART;VALUE=DATE:20240223",
+			"DTEND;VALUE=DATE:20240227",
+			"SUMMARY:Trip to the\\, beach",
+			" side",
+			"END:VEVENT",
+			"BEGIN:VEVENT",
+			"DTSTART:20240301T090000Z",
+			"DTEND:20240301T120000Z",
+			"SUMMARY:Exam",
+			"END:VEVENT",
+			"END:VCALENDAR",
+		}, "\r\n")
+
+		// Act
+		blackouts, err := planner.NewBlackoutsFromICS(strings.NewReader(ics))
+
+		// Assert
+		if !assert.Nil(t, err, "err from NewBlackoutsFromICS should be nil") || !assert.Len(t, blackouts, 2, "should have 2 blackouts") {
+			t.FailNow()
+		}
+		assert.Equal(t, "2024-02-23", blackouts[0].Start.Format(planner.LayoutDateOnly), "first start should be 2024-02-23")
+		assert.Equal(t, "2024-02-26", blackouts[0].End.Format(planner.LayoutDateOnly), "first end should be 2024-02-26, as DTEND is exclusive")
+		assert.Equal(t, "Trip to the, beachside", blackouts[0].Reason, "first reason should be unfolded and unescaped")
+		assert.Equal(t, "2024-03-01", blackouts[1].End.Format(planner.LayoutDateOnly), "timed event should block its own day")
+	})
+
+	t.Run("NewBlackoutsFromICS should ignore the properties of the alarms", func(t *testing.T) {
+		// Arrange
+		ics := strings.Join([]string{
+			"BEGIN:VCALENDAR",
+			"BEGIN:VEVENT",
+			"DTSTART;VALUE=DATE:20240223",
+			"SUMMARY:Exam",
+			"BEGIN:VALARM",
+			"TRIGGER:-PT15M",
+			"SUMMARY:Reminder",
+			"END:VALARM",
+			"END:VEVENT",
+			"END:VCALENDAR",
+		}, "\r\n")
+
+		// Act
+		blackouts, err := planner.NewBlackoutsFromICS(strings.NewReader(ics))
+
+		// Assert
+		if !assert.Nil(t, err, "err from NewBlackoutsFromICS should be nil") || !assert.Len(t, blackouts, 1, "should have 1 blackout") {
+			t.FailNow()
+		}
+		assert.Equal(t, "Exam", blackouts[0].Reason, "the reason should be the summary of the event")
+	})
+
+	t.Run("NewBlackoutsFromICS should move the UTC times to the local clock", func(t *testing.T) {
+		// Arrange
+		local := time.Local
+		time.Local = time.FixedZone("UTC-3", -3*60*60)
+		t.Cleanup(func() { time.Local = local })
+		ics := strings.Join([]string{
+			"BEGIN:VCALENDAR",
+			"BEGIN:VEVENT",
+			"DTSTART:20240301T020000Z",
+			"DTEND:20240301T030000Z",
+			"SUMMARY:Late exam",
+			"END:VEVENT",
+			"END:VCALENDAR",
+		}, "\r\n")
+
+		// Act
+		blackouts, err := planner.NewBlackoutsFromICS(strings.NewReader(ics))
+
+		// Assert
+		if !assert.Nil(t, err, "err from NewBlackoutsFromICS should be nil") || !assert.Len(t, blackouts, 1, "should have 1 blackout") {
+			t.FailNow()
+		}
+		assert.Equal(t, "2024-02-29", blackouts[0].Start.Format(planner.LayoutDateOnly), "the event starts at 23:00 of the day before on the local clock")
+		assert.Equal(t, "2024-02-29", blackouts[0].End.Format(planner.LayoutDateOnly), "the event ends at midnight on the local clock")
+	})
+
+	t.Run("NewBlackoutsFromICS should refuse recurring events", func(t *testing.T) {
+		for _, property := range []string{"RRULE:FREQ=WEEKLY;BYDAY=FR", "RDATE;VALUE=DATE:20240301", "EXDATE;VALUE=DATE:20240301"} {
+			// Arrange
+			ics := strings.Join([]string{
+				"BEGIN:VCALENDAR",
+				"BEGIN:VEVENT",
+				"DTSTART;VALUE=DATE:20240223",
+				property,
+				"SUMMARY:Exam day",
+				"END:VEVENT",
+				"END:VCALENDAR",
+			}, "\r\n")
+
+			// Act
+			_, err := planner.NewBlackoutsFromICS(strings.NewReader(ics))
+
+			// Assert
+			assert.ErrorIs(t, err, planner.ErrRecurringCalendarEvent, "'%s' should be refused", property)
+		}
+	})
+
+	t.Run("HourGrade.NextDate should skip blacked out dates", func(t *testing.T) {
+		// Arrange
+		start, _ := time.Parse(planner.LayoutTimeOnly, "14:00")
+		end, _ := time.Parse(planner.LayoutTimeOnly, "17:00")
+		hg := planner.NewHourGrade()
+		hg.Add(time.Monday, start, end)
+		hg.AddBlackouts(&planner.Blackout{
+			Start: time.Date(2024, 2, 26, 0, 0, 0, 0, time.UTC),
+			End:   time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC),
+		})
+		from := time.Date(2024, 2, 19, 0, 0, 0, 0, time.UTC)
+
+		// Act
+		date, err := hg.NextDate(from)
+
+		// Assert
+		assert.Nil(t, err, "err from NextDate should be nil")
+		assert.Equal(t, "2024-03-11", date.Format(planner.LayoutDateOnly), "next date should be the first monday after the blackout")
+	})
+}
@@ -0,0 +1,78 @@
+package planner
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+const breakPolicySeparator = "/"
+
+// BreakPolicy inserts a break after Focus of continuous study inside the same
+// time interval, no matter which disciplines were studied. Every LongBreakEvery
+// breaks, the break lasts LongBreak instead.
+type BreakPolicy struct {
+	Focus          time.Duration
+	Break          time.Duration
+	LongBreak      time.Duration
+	LongBreakEvery int
+}
+
+// breakBefore returns how long the break before the next content must be, or
+// zero if the content still fits on the current focus period.
+func (bp *BreakPolicy) breakBefore(focused time.Duration, next time.Duration, breaks int) time.Duration {
+	if bp == nil || focused == 0 || focused+next <= bp.Focus {
+		return 0
+	}
+
+	if bp.LongBreakEvery > 0 && (breaks+1)%bp.LongBreakEvery == 0 {
+		return bp.LongBreak
+	}
+
+	return bp.Break
+}
+
+// focusLeft is how long the study can go on before the next break.
+func (bp *BreakPolicy) focusLeft(focused time.Duration) time.Duration {
+	if bp == nil {
+		return -1
+	}
+
+	return bp.Focus - focused
+}
+
+// ParseBreakPolicy reads policies like "25m/5m" (a break of 5 minutes after 25
+// minutes of study) or "25m/5m/15m/4" (plus a long break of 15 minutes every 4 breaks).
+// The durations may be written in any way ParseDuration reads.
+func ParseBreakPolicy(value string, options ...InputOption) (*BreakPolicy, error) {
+	pieces := strings.Split(value, breakPolicySeparator)
+	if len(pieces) != 2 && len(pieces) != 4 {
+		return nil, ErrInvalidBreakPolicy
+	}
+
+	durations := make([]time.Duration, 3)
+	for index := 0; index < len(pieces) && index < 3; index++ {
+		duration, err := ParseDuration(pieces[index], options...)
+		if err != nil || duration <= 0 {
+			return nil, ErrInvalidBreakPolicy
+		}
+
+		durations[index] = duration
+	}
+
+	policy := &BreakPolicy{
+		Focus: durations[0],
+		Break: durations[1],
+	}
+	if len(pieces) == 4 {
+		every, err := strconv.Atoi(strings.TrimSpace(pieces[3]))
+		if err != nil || every <= 0 {
+			return nil, ErrInvalidBreakPolicy
+		}
+
+		policy.LongBreak = durations[2]
+		policy.LongBreakEvery = every
+	}
+
+	return policy, nil
+}
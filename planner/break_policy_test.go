@@ -0,0 +1,90 @@
+package planner_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaiquegarcia/gostudy/v2/planner"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_BreakPolicy(t *testing.T) {
+	t.Run("ParseBreakPolicy should accept short and long break policies", func(t *testing.T) {
+		// Act
+		short, shortErr := planner.ParseBreakPolicy("50m/10m")
+		long, longErr := planner.ParseBreakPolicy("25m/5m/15m/4")
+
+		// Assert
+		if !assert.Nil(t, shortErr, "err from the short policy should be nil") || !assert.Nil(t, longErr, "err from the long policy should be nil") {
+			t.FailNow()
+		}
+		assert.Equal(t, &planner.BreakPolicy{Focus: 50 * time.Minute, Break: 10 * time.Minute}, short)
+		assert.Equal(t, 15*time.Minute, long.LongBreak, "long break should be 15m")
+		assert.Equal(t, 4, long.LongBreakEvery, "long break should happen every 4 breaks")
+	})
+
+	t.Run("ParseBreakPolicy should read the durations like ParseDuration", func(t *testing.T) {
+		// Act
+		clock, clockErr := planner.ParseBreakPolicy("00:50:00/00:10:00")
+		iso, isoErr := planner.ParseBreakPolicy("PT25M/PT5M/PT15M/4")
+		short, shortErr := planner.ParseBreakPolicy("00:50/00:10", planner.WithShortDurationMode(planner.DurationModeHoursMinutes))
+
+		// Assert
+		assert.Nil(t, clockErr, "err from the clock policy should be nil")
+		assert.Nil(t, isoErr, "err from the ISO 8601 policy should be nil")
+		assert.Nil(t, shortErr, "err from the short policy should be nil")
+		assert.Equal(t, &planner.BreakPolicy{Focus: 50 * time.Minute, Break: 10 * time.Minute}, clock)
+		assert.Equal(t, &planner.BreakPolicy{Focus: 25 * time.Minute, Break: 5 * time.Minute, LongBreak: 15 * time.Minute, LongBreakEvery: 4}, iso)
+		assert.Equal(t, &planner.BreakPolicy{Focus: 50 * time.Minute, Break: 10 * time.Minute}, short)
+	})
+
+	t.Run("ParseBreakPolicy should refuse malformed policies", func(t *testing.T) {
+		for _, value := range []string{"", "25m", "25m/5m/15m", "25m/five", "25m/5m/15m/0", "-25m/5m", "0m/5m", "00:00:00/00:05:00"} {
+			// Act
+			_, err := planner.ParseBreakPolicy(value)
+
+			// Assert
+			assert.ErrorIs(t, err, planner.ErrInvalidBreakPolicy, "'%s' should be refused", value)
+		}
+	})
+}
+
+func Test_Maker_Breaks(t *testing.T) {
+	t.Run("should take the short and long breaks, even when the discipline changes", func(t *testing.T) {
+		// Arrange
+		hg := testHourGrade(t, "14:00-17:00")
+		disciplines := testDisciplines(t, [][]string{
+			{"Name", "Filename", "Daily Limit", "Content Gap", "Subject Gap"},
+			{"Math", "math.csv", "01:30:00", "00:00:00", "00:00:00"},
+			{"History", "history.csv", "01:00:00", "00:00:00", "00:00:00"},
+		}, map[string][][]string{
+			"math.csv": {
+				{"Logic", "Sets", "00:30:00"},
+				{"Logic", "Relations", "00:30:00"},
+				{"Logic", "Functions", "00:30:00"},
+			},
+			"history.csv": {
+				{"Ancient", "Egypt", "00:30:00"},
+				{"Ancient", "Rome", "00:30:00"},
+			},
+		})
+		policy, err := planner.ParseBreakPolicy("30m/5m/15m/2")
+		if !assert.Nil(t, err, "err from ParseBreakPolicy should be nil") {
+			t.FailNow()
+		}
+
+		// Act
+		outputs, err := mountOutputs(t, hg, disciplines, planner.WithBreakPolicy(policy))
+
+		// Assert
+		assert.Nil(t, err, "err from Mount should be nil")
+		assert.Equal(t, []string{
+			"2024-02-21 14:00 Math Sets",
+			"2024-02-21 14:35 Math Relations",
+			"2024-02-21 15:20 Math Functions",
+			"2024-02-21 15:55 History Egypt",
+			// the 4th break is long, so rome doesn't fit before 17:00
+			"2024-02-22 14:00 History Rome",
+		}, outputs)
+	})
+}
@@ -1,5 +1,35 @@
 package planner
 
+import "time"
+
 const (
 	MaxContentAttemptsAllowed = 10
+	MinSplitPartDuration      = 10 * time.Minute
+	// estimated daily limits grow by EstimateStep while the simulated plan still ends too late
+	EstimateStep          = 15 * time.Minute
+	EstimateRounding      = 5 * time.Minute
+	MaxEstimateIterations = 20
+)
+
+// the columns of the contents files, found by name when the header has the Duration one
+const (
+	ColumnSubject       = "Subject"
+	ColumnTitle         = "Title"
+	ColumnDuration      = "Duration"
+	ColumnReference     = "Reference"
+	ColumnID            = "ID"
+	ColumnPrerequisites = "Prerequisites"
+)
+
+const (
+	ColumnWeight          = "Weight"
+	ColumnDeadline        = "Deadline"
+	ColumnSplittable      = "Splittable"
+	ColumnReviewIntervals = "Review Intervals"
+	ColumnReviewDuration  = "Review Duration"
+	ColumnWeeklyLimit     = "Weekly Limit"
+	ColumnMinWeekly       = "Min Weekly"
+	ColumnWeekdays        = "Weekdays"
+	ColumnTimeWindows     = "Time Windows"
+	ColumnReorderable     = "Reorderable"
 )
@@ -1,37 +1,106 @@
 package planner
 
 import (
+	"strings"
 	"time"
+
+	"github.com/kaiquegarcia/gostudy/v2/stream"
 )
 
+const idListSeparator = ";"
+
 type Content struct {
-	Subject   string
-	Title     string
-	Duration  time.Duration
-	Reference string
-	Attempts  int
+	ID            string
+	Subject       string
+	Title         string
+	Duration      time.Duration
+	Reference     string
+	Prerequisites []string
+	// Extra has the columns of the contents file that gostudy doesn't use, keyed by their names
+	Extra    map[string]string
+	Attempts int
+	// set when the ID comes from the row, as the content has no ID of its own
+	positionalID bool
+	// set when the content was split to fit on the plan
+	split        *contentSplit
+	hasRemainder bool
 }
 
-func newContentFromRow(columns []string) (*Content, error) {
-	// Subject, Title, Duration, Reference
-	if len(columns) != 4 {
+var contentColumns = []string{ColumnSubject, ColumnTitle, ColumnDuration, ColumnReference, ColumnID, ColumnPrerequisites}
+
+// newContentFromRecord finds the columns by their names, so they may be in any
+// order, among columns of the user. Headers without a Duration column, like the
+// ones in other languages, are read by position.
+func newContentFromRecord(record stream.Record, options ...InputOption) (*Content, error) {
+	if !record.Header.Has(ColumnDuration) {
+		return newContentFromRow(record.Columns, options...)
+	}
+
+	if len(record.Columns) > len(record.Header.Names()) {
 		return nil, ErrUnexpectedColumnsLength
 	}
 
-	duration, err := parseDuration(columns[2])
+	duration, err := ParseDuration(record.Get(ColumnDuration), options...)
 	if err != nil {
 		return nil, err
 	}
 
 	return &Content{
-		Subject:   columns[0],
-		Title:     columns[1],
-		Duration:  duration,
-		Reference: columns[3],
-		Attempts:  0,
+		ID:            record.Get(ColumnID),
+		Subject:       record.Get(ColumnSubject),
+		Title:         record.Get(ColumnTitle),
+		Duration:      duration,
+		Reference:     record.Get(ColumnReference),
+		Prerequisites: parseIDList(record.Get(ColumnPrerequisites)),
+		Extra:         record.Extra(contentColumns...),
+		Attempts:      0,
 	}, nil
 }
 
+func newContentFromRow(columns []string, options ...InputOption) (*Content, error) {
+	// Subject, Title, Duration, Reference, [ID], [Prerequisites]
+	if len(columns) < 4 || len(columns) > 6 {
+		return nil, ErrUnexpectedColumnsLength
+	}
+
+	duration, err := ParseDuration(columns[2], options...)
+	if err != nil {
+		return nil, err
+	}
+
+	content := &Content{
+		Subject:       columns[0],
+		Title:         columns[1],
+		Duration:      duration,
+		Reference:     columns[3],
+		Prerequisites: make([]string, 0),
+		Extra:         map[string]string{},
+		Attempts:      0,
+	}
+
+	if len(columns) > 4 {
+		content.ID = columns[4]
+	}
+
+	if len(columns) > 5 {
+		content.Prerequisites = parseIDList(columns[5])
+	}
+
+	return content, nil
+}
+
+func parseIDList(value string) []string {
+	ids := make([]string, 0)
+	for _, id := range strings.Split(value, idListSeparator) {
+		id = strings.TrimSpace(id)
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
 func (c *Content) IsBetween(start time.Time, end time.Time) bool {
 	return !start.Add(c.Duration).After(end)
 }
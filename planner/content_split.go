@@ -0,0 +1,25 @@
+package planner
+
+import "fmt"
+
+type contentSplit struct {
+	whole *Content
+	parts []*Content
+}
+
+// partNumber counts the parts from 1, or returns 0 if the part isn't placed yet.
+func (cs *contentSplit) partNumber(part *Content) int {
+	for index, placed := range cs.parts {
+		if placed == part {
+			return index + 1
+		}
+	}
+
+	return 0
+}
+
+func (cs *contentSplit) nameParts() {
+	for index, part := range cs.parts {
+		part.Title = fmt.Sprintf("%s (part %d/%d)", cs.whole.Title, index+1, len(cs.parts))
+	}
+}
@@ -1,10 +1,13 @@
 package planner_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/kaiquegarcia/gostudy/v2/planner"
+	"github.com/kaiquegarcia/gostudy/v2/stream"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -49,3 +52,118 @@ func Test_Content(t *testing.T) {
 		assert.False(t, result, "result should be false")
 	})
 }
+
+func Test_Content_Columns(t *testing.T) {
+	t.Run("should find the content columns by name and keep the extra ones", func(t *testing.T) {
+		// Arrange
+		disciplineRows := [][]string{
+			{"Name", "Filename", "Daily Limit", "Content Gap", "Subject Gap"},
+			{"Math", "math.csv", "01:00:00", "00:00:00", "00:00:00"},
+		}
+		contents := map[string][][]string{
+			"math.csv": {
+				{"Title", "Priority", "Duration (hh:mm:ss)", "Subject", "Prerequisites", "ID"},
+				{"Sets", "high", "00:30:00", "Logic", "", "math-1"},
+				{"Induction", "low", "00:45:00", "Logic", "math-1", ""},
+			},
+		}
+		disciplines, err := planner.NewDisciplineFromRowsWithContents(disciplineRows, contents)
+		if !assert.Nil(t, err, "err from NewDisciplineFromRowsWithContents should be nil") {
+			t.FailNow()
+		}
+		defer disciplines[0].Close()
+
+		// Act
+		first, firstErr := disciplines[0].Next()
+		second, secondErr := disciplines[0].Next()
+
+		// Assert
+		assert.Nil(t, firstErr, "err from first Next should be nil")
+		assert.Nil(t, secondErr, "err from second Next should be nil")
+		assert.Equal(t, "math-1", first.ID)
+		assert.Equal(t, "Logic", first.Subject)
+		assert.Equal(t, "Sets", first.Title)
+		assert.Equal(t, 30*time.Minute, first.Duration)
+		assert.Equal(t, map[string]string{"Priority": "high"}, first.Extra)
+		assert.Equal(t, "math.csv#2", second.ID, "rows without ID should be identified by position")
+		assert.Equal(t, []string{"math-1"}, second.Prerequisites)
+	})
+
+	t.Run("should read the columns by position when the header doesn't name them", func(t *testing.T) {
+		// Arrange
+		disciplineRows := [][]string{
+			{"Name", "Filename", "Daily Limit", "Content Gap", "Subject Gap"},
+			{"Lógica", "logica.csv", "01:00:00", "00:00:00", "00:00:00"},
+		}
+		contents := map[string][][]string{
+			"logica.csv": {
+				{"Assunto", "Título", "Duração", "Referência"},
+				{"Conjuntos", "Relações", "00:10:00", "livro"},
+			},
+		}
+		disciplines, err := planner.NewDisciplineFromRowsWithContents(disciplineRows, contents)
+		if !assert.Nil(t, err, "err from NewDisciplineFromRowsWithContents should be nil") {
+			t.FailNow()
+		}
+		defer disciplines[0].Close()
+
+		// Act
+		content, err := disciplines[0].Next()
+
+		// Assert
+		assert.Nil(t, err, "err from Next should be nil")
+		assert.Equal(t, "Relações", content.Title)
+		assert.Equal(t, 10*time.Minute, content.Duration)
+		assert.Equal(t, "livro", content.Reference)
+	})
+
+	t.Run("should read the contents from JSON Lines", func(t *testing.T) {
+		// Arrange
+		data := []byte(`{"id": "math-1", "subject": "Logic", "title": "Sets", "duration": "30m", "tags": ["basics", "sets"]}
+{"subject": "Logic", "title": "Induction", "duration": "PT45M", "prerequisites": ["math-1"]}
+`)
+		discipline, err := planner.NewDisciplineFromSource("Math", "math.jsonl", planner.JSONLinesContentSource(data), time.Hour, 0, 0)
+		if !assert.Nil(t, err, "err from NewDisciplineFromSource should be nil") {
+			t.FailNow()
+		}
+		defer discipline.Close()
+
+		// Act
+		contents, err := discipline.Contents()
+
+		// Assert
+		if !assert.Nil(t, err, "err from Contents should be nil") || !assert.Len(t, contents, 2, "should have 2 contents") {
+			t.FailNow()
+		}
+		assert.Equal(t, "math-1", contents[0].ID)
+		assert.Equal(t, 30*time.Minute, contents[0].Duration)
+		assert.Equal(t, map[string]string{"tags": "basics;sets"}, contents[0].Extra)
+		assert.Equal(t, "math.jsonl#2", contents[1].ID)
+		assert.Equal(t, []string{"math-1"}, contents[1].Prerequisites)
+	})
+
+	t.Run("should open the contents files with the CSV options", func(t *testing.T) {
+		// Arrange
+		filename := filepath.Join(t.TempDir(), "math.csv")
+		err := os.WriteFile(filename, []byte("Subject;Title (a, b, c);Duration\nLogic;Sets, Relations;00:10:00\n"), 0o644)
+		if !assert.Nil(t, err, "err from WriteFile should be nil") {
+			t.FailNow()
+		}
+		disciplineRows := [][]string{
+			{"Name", "Filename", "Daily Limit", "Content Gap", "Subject Gap"},
+			{"Math", filename, "01:00:00", "00:00:00", "00:00:00"},
+		}
+		disciplines, err := planner.NewDisciplineFromRows(disciplineRows, planner.WithCSVOptions(stream.WithDelimiter(';')))
+		if !assert.Nil(t, err, "err from NewDisciplineFromRows should be nil") {
+			t.FailNow()
+		}
+		defer disciplines[0].Close()
+
+		// Act
+		content, err := disciplines[0].Next()
+
+		// Assert
+		assert.Nil(t, err, "err from Next should be nil")
+		assert.Equal(t, "Sets, Relations", content.Title)
+	})
+}
@@ -1,19 +1,87 @@
 package planner
 
 import (
+	"fmt"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/kaiquegarcia/gostudy/v2/stream"
 )
 
+const timeWindowListSeparator = ";"
+
 type Discipline struct {
-	Name          string
-	Filename      string
-	DailyLimit    time.Duration
-	ContentGap    time.Duration
-	SubjectGap    time.Duration
-	contentStream stream.DataStream
+	Name            string
+	Filename        string
+	DailyLimit      time.Duration
+	ContentGap      time.Duration
+	SubjectGap      time.Duration
+	Weight          int
+	Deadline        time.Time
+	Splittable      bool
+	ReviewIntervals []int
+	ReviewDuration  ReviewDuration
+	WeeklyLimit     time.Duration
+	MinWeekly       time.Duration
+	Weekdays        []time.Weekday
+	TimeWindows     []*HourGradeInterval
+	Reorderable     bool
+	contentStream   *stream.RecordReader
+	inputOptions    []InputOption
+	held            []*Content
+	lastHeld        *Content
+}
+
+// ContentSource opens a new stream of the discipline's contents, header included.
+type ContentSource func() (stream.DataStream, error)
+
+// FileContentSource reads JSON Lines files (.jsonl or .ndjson), one content
+// per line, or CSV files otherwise, opened with the options.
+func FileContentSource(filename string, options ...stream.CSVOption) ContentSource {
+	return func() (stream.DataStream, error) {
+		contentFile, err := os.Open(filename)
+		if err != nil {
+			return nil, err
+		}
+
+		var contentStream stream.DataStream
+		switch strings.ToLower(filepath.Ext(filename)) {
+		case ".jsonl", ".ndjson":
+			contentStream, err = stream.NewJSONLinesDataStream(contentFile)
+		default:
+			contentStream, err = stream.NewCSVDataStream(contentFile, options...)
+		}
+
+		if err != nil {
+			contentFile.Close()
+			return nil, err
+		}
+
+		return contentStream, nil
+	}
+}
+
+// CSVContentSource reads the contents from CSV data already in memory, like embedded files.
+func CSVContentSource(data []byte, options ...stream.CSVOption) ContentSource {
+	return func() (stream.DataStream, error) {
+		return stream.NewCSVDataStreamFromBytes(data, options...)
+	}
+}
+
+// JSONLinesContentSource reads the contents from JSON Lines data already in memory.
+func JSONLinesContentSource(data []byte) ContentSource {
+	return func() (stream.DataStream, error) {
+		return stream.NewJSONLinesDataStreamFromBytes(data)
+	}
+}
+
+func MemoryContentSource(rows [][]string) ContentSource {
+	return func() (stream.DataStream, error) {
+		return stream.NewMemoryDataStream(rows), nil
+	}
 }
 
 func NewDiscipline(
@@ -22,30 +90,48 @@ func NewDiscipline(
 	dailyLimit time.Duration,
 	contentGap time.Duration,
 	subjectGap time.Duration,
+	options ...InputOption,
 ) (*Discipline, error) {
-	contentFile, err := os.Open(filename)
-	if err != nil {
-		return nil, err
-	}
+	source := FileContentSource(filename, newInputSettings(options).csvOptions...)
+	return NewDisciplineFromSource(name, filename, source, dailyLimit, contentGap, subjectGap, options...)
+}
 
-	contentStream, err := stream.NewCSVDataStream(contentFile)
+// NewDisciplineFromSource reads the contents from the source instead of the
+// file, which still names the contents without ID. The options are used to
+// read the durations of the contents.
+func NewDisciplineFromSource(
+	name string,
+	filename string,
+	source ContentSource,
+	dailyLimit time.Duration,
+	contentGap time.Duration,
+	subjectGap time.Duration,
+	options ...InputOption,
+) (*Discipline, error) {
+	dataStream, err := source()
 	if err != nil {
 		return nil, err
 	}
 
-	// sets pointer to first row, skipping header
-	_, err = contentStream.Read()
+	// sets pointer to first row, keeping the header to find the columns
+	contentStream, err := stream.NewRecordReader(dataStream)
 	if err != nil {
+		dataStream.Close()
 		return nil, err
 	}
 
 	return &Discipline{
-		Name:          name,
-		Filename:      filename,
-		DailyLimit:    dailyLimit,
-		ContentGap:    contentGap,
-		SubjectGap:    subjectGap,
-		contentStream: contentStream,
+		Name:            name,
+		Filename:        filename,
+		DailyLimit:      dailyLimit,
+		ContentGap:      contentGap,
+		SubjectGap:      subjectGap,
+		Weight:          1,
+		ReviewIntervals: make([]int, 0),
+		ReviewDuration:  ReviewDuration{Ratio: 1},
+		contentStream:   contentStream,
+		inputOptions:    options,
+		held:            make([]*Content, 0),
 	}, nil
 }
 
@@ -54,58 +140,370 @@ func (d *Discipline) Close() error {
 }
 
 func (d *Discipline) Next() (*Content, error) {
-	columns, err := d.contentStream.Read()
+	if len(d.held) > 0 {
+		d.lastHeld = d.held[0]
+		d.held = d.held[1:]
+		return d.lastHeld, nil
+	}
+
+	d.lastHeld = nil
+	return d.readContent()
+}
+
+func (d *Discipline) readContent() (*Content, error) {
+	record, err := d.contentStream.ReadRecord()
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := newContentFromRecord(record, d.inputOptions...)
 	if err != nil {
 		return nil, err
 	}
 
-	return newContentFromRow(columns)
+	if content.ID == "" {
+		// rows without ID are still identifiable by their position, the header is the row 0
+		content.ID = fmt.Sprintf("%s#%d", d.Filename, d.contentStream.Mark().Row()-1)
+		content.positionalID = true
+	}
+
+	return content, nil
 }
 
 func (d *Discipline) Back() error {
+	if d.lastHeld != nil {
+		d.Hold(d.lastHeld)
+		d.lastHeld = nil
+		return nil
+	}
+
 	return d.contentStream.Unread()
 }
 
-func NewDisciplineFromRows(rows [][]string) ([]*Discipline, error) {
+// Hold puts the content in front of the stream, so it's the next one returned by Next.
+func (d *Discipline) Hold(content *Content) {
+	d.held = append([]*Content{content}, d.held...)
+}
+
+// Lookahead returns up to n next contents without consuming them, as they're
+// held in the same order to be returned by Next.
+func (d *Discipline) Lookahead(n int) ([]*Content, error) {
+	contents := make([]*Content, 0, n)
+	for len(contents) < n {
+		content, err := d.Next()
+		if err == stream.ErrEOF {
+			break
+		}
+
+		if err != nil {
+			d.held = append(contents, d.held...)
+			return nil, err
+		}
+
+		contents = append(contents, content)
+	}
+
+	d.held = append(append(make([]*Content, 0, len(contents)+len(d.held)), contents...), d.held...)
+	d.lastHeld = nil
+	return contents, nil
+}
+
+// Promote moves a content returned by Lookahead to the front, so it's the next one returned by Next.
+func (d *Discipline) Promote(content *Content) {
+	for index, held := range d.held {
+		if held == content {
+			d.held = append(d.held[:index], d.held[index+1:]...)
+			break
+		}
+	}
+
+	d.Hold(content)
+}
+
+type ContentStats struct {
+	Count   int
+	Total   time.Duration
+	Longest time.Duration
+	Reviews time.Duration
+}
+
+// Contents reads every content of the discipline, from the first one, then
+// goes back to the current position of the stream.
+func (d *Discipline) Contents() ([]*Content, error) {
+	mark := d.contentStream.Mark()
+	contents, err := d.readAllContents()
+	resetErr := d.contentStream.Reset(mark)
+	if err != nil {
+		return nil, err
+	}
+
+	if resetErr != nil {
+		return nil, resetErr
+	}
+
+	return contents, nil
+}
+
+func (d *Discipline) readAllContents() ([]*Content, error) {
+	// skipping header
+	err := d.contentStream.Seek(1)
+	if err != nil {
+		return nil, err
+	}
+
+	contents := make([]*Content, 0)
+	for {
+		content, err := d.readContent()
+		if err == stream.ErrEOF {
+			return contents, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		contents = append(contents, content)
+	}
+}
+
+func (d *Discipline) Stats() (*ContentStats, error) {
+	contents, err := d.Contents()
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &ContentStats{}
+	for _, content := range contents {
+		stats.Count++
+		stats.Total += content.Duration
+		stats.Reviews += time.Duration(len(d.ReviewIntervals)) * d.ReviewDuration.For(content)
+		if content.Duration > stats.Longest {
+			stats.Longest = content.Duration
+		}
+	}
+
+	return stats, nil
+}
+
+func (d *Discipline) Workload() (time.Duration, error) {
+	stats, err := d.Stats()
+	if err != nil {
+		return 0, err
+	}
+
+	return stats.Total, nil
+}
+
+func parseWeight(value string) (int, error) {
+	if value == "" {
+		return 1, nil
+	}
+
+	weight, err := strconv.Atoi(value)
+	if err != nil || weight <= 0 {
+		return 0, ErrInvalidWeight
+	}
+
+	return weight, nil
+}
+
+func NewDisciplineFromRows(rows [][]string, options ...InputOption) ([]*Discipline, error) {
+	return NewDisciplineFromRowsWithContents(rows, nil, options...)
+}
+
+// NewDisciplineFromRowsWithContents reads the contents of the disciplines
+// whose filenames are keys of contents from its rows (header included), instead of the files.
+func NewDisciplineFromRowsWithContents(rows [][]string, contents map[string][][]string, options ...InputOption) ([]*Discipline, error) {
 	disciplines := make([]*Discipline, 0)
+	if len(rows) == 0 {
+		return disciplines, nil
+	}
+
+	settings := newInputSettings(options)
+	header := stream.NewHeader(rows[0])
 	for line := 1; line < len(rows); line++ {
 		columns := rows[line]
 		if len(columns) == 0 {
 			break
 		}
-		// Name, Filename, Daily Limit, Content Gap, Subject Gap
-		if len(columns) != 5 {
+		// Name, Filename, Daily Limit, Content Gap, Subject Gap, [optional columns by header name]
+		if len(columns) < 5 {
 			return nil, ErrUnexpectedColumnsLength
 		}
 
-		dailyLimit, err := parseDuration(columns[2])
+		record := stream.Record{Header: header, Columns: columns}
+
+		dailyLimit, err := ParseDuration(columns[2], options...)
 		if err != nil {
 			return nil, err
 		}
 
-		contentGap, err := parseDuration(columns[3])
+		contentGap, err := ParseDuration(columns[3], options...)
 		if err != nil {
 			return nil, err
 		}
 
-		subjectGap, err := parseDuration(columns[4])
+		subjectGap, err := ParseDuration(columns[4], options...)
 		if err != nil {
 			return nil, err
 		}
 
-		discipline, err := NewDiscipline(
+		source := FileContentSource(columns[1], settings.csvOptions...)
+		if contentRows, exists := contents[columns[1]]; exists {
+			source = MemoryContentSource(contentRows)
+		}
+
+		discipline, err := NewDisciplineFromSource(
 			columns[0],
 			columns[1],
+			source,
 			dailyLimit,
 			contentGap,
 			subjectGap,
+			options...,
 		)
 		if err != nil {
 			return nil, err
 		}
 
+		discipline.Weight, err = parseWeight(record.Get(ColumnWeight))
+		if err != nil {
+			discipline.Close()
+			return nil, err
+		}
+
+		discipline.Deadline, err = parseDeadline(record.Get(ColumnDeadline))
+		if err != nil {
+			discipline.Close()
+			return nil, err
+		}
+
+		discipline.Splittable, err = parseBool(record.Get(ColumnSplittable))
+		if err != nil {
+			discipline.Close()
+			return nil, err
+		}
+
+		discipline.ReviewIntervals, err = parseReviewIntervals(record.Get(ColumnReviewIntervals))
+		if err != nil {
+			discipline.Close()
+			return nil, err
+		}
+
+		discipline.ReviewDuration, err = parseReviewDuration(record.Get(ColumnReviewDuration), options...)
+		if err != nil {
+			discipline.Close()
+			return nil, err
+		}
+
+		discipline.WeeklyLimit, discipline.MinWeekly, err = parseWeeklyLimits(
+			record.Get(ColumnWeeklyLimit),
+			record.Get(ColumnMinWeekly),
+			options...,
+		)
+		if err != nil {
+			discipline.Close()
+			return nil, err
+		}
+
+		discipline.Reorderable, err = parseBool(record.Get(ColumnReorderable))
+		if err != nil {
+			discipline.Close()
+			return nil, err
+		}
+
+		discipline.Weekdays, err = parseWeekdays(record.Get(ColumnWeekdays))
+		if err != nil {
+			discipline.Close()
+			return nil, err
+		}
+
+		discipline.TimeWindows, err = parseTimeWindows(record.Get(ColumnTimeWindows))
+		if err != nil {
+			discipline.Close()
+			return nil, err
+		}
+
 		disciplines = append(disciplines, discipline)
 	}
 
 	return disciplines, nil
 }
+
+func parseDeadline(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	return time.Parse(LayoutDateOnly, value)
+}
+
+func parseBool(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "", "0", "n", "no", "false":
+		return false, nil
+	case "1", "y", "yes", "true":
+		return true, nil
+	}
+
+	return false, ErrInvalidBoolean
+}
+
+// parseWeeklyLimits reads both weekly budgets, where empty means there's no budget.
+func parseWeeklyLimits(limitValue string, minimumValue string, options ...InputOption) (time.Duration, time.Duration, error) {
+	var limit, minimum time.Duration
+	var err error
+	if limitValue != "" {
+		limit, err = ParseDuration(limitValue, options...)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
+	if minimumValue != "" {
+		minimum, err = ParseDuration(minimumValue, options...)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
+	if limit > 0 && minimum > limit {
+		return 0, 0, ErrInvalidWeeklyLimits
+	}
+
+	return limit, minimum, nil
+}
+
+func parseTimeWindows(value string) ([]*HourGradeInterval, error) {
+	windows := make([]*HourGradeInterval, 0)
+	if value == "" {
+		return windows, nil
+	}
+
+	for _, entry := range strings.Split(value, timeWindowListSeparator) {
+		start, end, err := parseInterval(strings.TrimSpace(entry))
+		if err != nil {
+			return nil, err
+		}
+
+		if !start.Before(end) {
+			return nil, ErrInvalidTimeWindow
+		}
+
+		windows = append(windows, &HourGradeInterval{Start: start, End: end})
+	}
+
+	return windows, nil
+}
+
+// longestWindow returns zero when the discipline has no time windows.
+func (d *Discipline) longestWindow() time.Duration {
+	var longest time.Duration = 0
+	for _, window := range d.TimeWindows {
+		if duration := window.End.Sub(window.Start); duration > longest {
+			longest = duration
+		}
+	}
+
+	return longest
+}
@@ -3,10 +3,32 @@ package planner
 import "fmt"
 
 var (
-	ErrInvalidDurationFormat     = fmt.Errorf("the duration doesn't follow the hh:mm:ss pattern")
+	ErrInvalidDurationFormat     = fmt.Errorf("the duration must follow hh:mm:ss, hh:mm or mm:ss, or be like 1h30m or PT1H30M")
+	ErrUnknownDurationMode       = fmt.Errorf("the short duration mode must be hh:mm or mm:ss")
 	ErrUnavailableWeekdays       = fmt.Errorf("could not find any weekday with available hour grade intervals")
 	ErrUnexpectedColumnsLength   = fmt.Errorf("the columns number doesn't match with the required count")
 	ErrUnexpectedIntervalLength  = fmt.Errorf("the time interval must have only two elements, the beginning and the end of the interval")
+	ErrInvalidClockTime          = fmt.Errorf("the time must follow hh:mm or the 12-hour clock, like 7:30pm")
 	ErrUnexpectedGradeLength     = fmt.Errorf("the hour grade spreadsheet must have at least 7 rows, one row per day of week")
 	ErrContentDurationUnplayable = fmt.Errorf("content duration is unplayable")
+	ErrInvalidReviewIntervals    = fmt.Errorf("the review intervals must be positive numbers of days separated by ';'")
+	ErrInvalidReviewDuration     = fmt.Errorf("the review duration must be a duration like hh:mm:ss or a positive percentage like 25%%")
+	ErrInvalidWeight             = fmt.Errorf("the weight must be a positive integer")
+	ErrUnknownSelectionStrategy  = fmt.Errorf("unknown discipline selection strategy")
+	ErrInvalidBoolean            = fmt.Errorf("the value must be yes or no")
+	ErrUnresolvablePrerequisites = fmt.Errorf("the remaining contents are waiting for prerequisites that will never be placed")
+	ErrInvalidDateRange          = fmt.Errorf("the end date must not be before the start date")
+	ErrInvalidWeekday            = fmt.Errorf("the weekday must be an english name like 'monday' or 'mon', or 'weekdays' or 'weekends'")
+	ErrInvalidCalendarEvent      = fmt.Errorf("the calendar event must have a start date")
+	ErrRecurringCalendarEvent    = fmt.Errorf("the recurring calendar events aren't supported, each date must be an event of its own")
+	ErrInvalidWeeklyLimits       = fmt.Errorf("the weekly minimum must not be higher than the weekly limit")
+	ErrInvalidBreakPolicy        = fmt.Errorf("the break policy must follow the focus/break or focus/break/long break/every pattern, like 25m/5m/15m/4")
+	ErrInvalidTimeWindow         = fmt.Errorf("the time window must end after it starts")
+	ErrDisciplineNeverAllowed    = fmt.Errorf("the discipline's weekdays and time windows don't match any interval of the hour grade")
+	ErrInvalidProgressStatus     = fmt.Errorf("the progress status must be done or skipped")
+	ErrUnknownOutputFormat       = fmt.Errorf("unknown output format")
+	ErrUnknownProjectFormat      = fmt.Errorf("the project file must be .yaml, .yml or .json")
+	ErrInvalidProjectDiscipline  = fmt.Errorf("each discipline of the project must have either a file or inline contents")
+	ErrDuplicateProjectContents  = fmt.Errorf("the disciplines with inline contents must have unique names, different from the files of the other disciplines")
+	ErrDeadlineMissed            = fmt.Errorf("at least one discipline can't finish before its deadline")
 )
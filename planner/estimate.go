@@ -0,0 +1,187 @@
+package planner
+
+import (
+	"time"
+
+	"github.com/kaiquegarcia/gostudy/v2/logging"
+)
+
+type DisciplineEstimate struct {
+	Discipline *Discipline
+	// Workload includes the contents, their reviews and the gaps between contents
+	Workload   time.Duration
+	Longest    time.Duration
+	DailyLimit time.Duration
+}
+
+// Estimate proposes the daily limits each discipline needs to finish between
+// StartDate and EndDate.
+type Estimate struct {
+	StartDate          time.Time
+	EndDate            time.Time
+	StudyDays          int
+	Capacity           time.Duration
+	Workload           time.Duration
+	MissingPerStudyDay time.Duration
+	Disciplines        []*DisciplineEstimate
+}
+
+func NewEstimate(hg *HourGrade, disciplines []*Discipline, startDate time.Time, endDate time.Time) (*Estimate, error) {
+	if dateOnly(endDate).Before(dateOnly(startDate)) {
+		return nil, ErrInvalidDateRange
+	}
+
+	estimate := &Estimate{
+		StartDate:   startDate,
+		EndDate:     endDate,
+		Disciplines: make([]*DisciplineEstimate, len(disciplines)),
+	}
+	for date := startDate; !dateOnly(date).After(dateOnly(endDate)); date = date.AddDate(0, 0, 1) {
+		if !hg.HasGradeFor(date) {
+			continue
+		}
+
+		estimate.StudyDays++
+		estimate.Capacity += hg.CapacityFor(date)
+	}
+
+	if estimate.StudyDays == 0 {
+		return nil, ErrUnavailableWeekdays
+	}
+
+	for index, discipline := range disciplines {
+		stats, err := discipline.Stats()
+		if err != nil {
+			return nil, err
+		}
+
+		workload := stats.Total + stats.Reviews
+		if stats.Count > 1 {
+			workload += time.Duration(stats.Count-1) * discipline.ContentGap
+		}
+
+		dailyLimit := roundUpDuration(workload/time.Duration(estimate.StudyDays), EstimateRounding)
+		if !discipline.Splittable && stats.Longest > dailyLimit {
+			// a content that can't be split must fit on a single day
+			dailyLimit = stats.Longest
+		}
+
+		estimate.Workload += workload
+		estimate.Disciplines[index] = &DisciplineEstimate{
+			Discipline: discipline,
+			Workload:   workload,
+			Longest:    stats.Longest,
+			DailyLimit: dailyLimit,
+		}
+	}
+
+	if estimate.Workload > estimate.Capacity {
+		missing := (estimate.Workload - estimate.Capacity) / time.Duration(estimate.StudyDays)
+		estimate.MissingPerStudyDay = roundUpDuration(missing, EstimateRounding)
+	}
+
+	return estimate, nil
+}
+
+// Increase raises the daily limit of every discipline finishing after the end
+// date on the simulated report. It returns false when nothing was changed.
+func (e *Estimate) Increase(report *Report) bool {
+	late := e.late(report)
+	for _, index := range late {
+		e.Disciplines[index].DailyLimit += EstimateStep
+	}
+
+	return len(late) > 0
+}
+
+// DailyLimits copies the current estimated daily limits, so they can be
+// restored if the next increases don't pay off.
+func (e *Estimate) DailyLimits() []time.Duration {
+	limits := make([]time.Duration, len(e.Disciplines))
+	for index, de := range e.Disciplines {
+		limits[index] = de.DailyLimit
+	}
+
+	return limits
+}
+
+func (e *Estimate) RestoreDailyLimits(limits []time.Duration) {
+	for index, de := range e.Disciplines {
+		de.DailyLimit = limits[index]
+	}
+}
+
+// Improves tells if the current simulated report ends sooner or has less late
+// disciplines than the previous one.
+func (e *Estimate) Improves(previous *Report, current *Report) bool {
+	return current.EndDate.Before(previous.EndDate) || len(e.late(current)) < len(e.late(previous))
+}
+
+func (e *Estimate) late(report *Report) []int {
+	indexes := make([]int, 0)
+	for index, dr := range report.Disciplines {
+		if !dr.FinishDate.Before(e.limit()) {
+			indexes = append(indexes, index)
+		}
+	}
+
+	return indexes
+}
+
+// Fits tells if the simulated report ends on or before the end date.
+func (e *Estimate) Fits(report *Report) bool {
+	return report.EndDate.Before(e.limit())
+}
+
+func (e *Estimate) limit() time.Time {
+	return dateOnly(e.EndDate).AddDate(0, 0, 1)
+}
+
+// ApplyTo returns a copy of the disciplines list rows (header included) with
+// the estimated daily limits.
+func (e *Estimate) ApplyTo(rows [][]string) [][]string {
+	proposed := make([][]string, len(rows))
+	for line, columns := range rows {
+		proposed[line] = append([]string{}, columns...)
+		if line == 0 || line > len(e.Disciplines) || len(columns) < 3 {
+			continue
+		}
+
+		proposed[line][2] = FormatDuration(e.Disciplines[line-1].DailyLimit)
+	}
+
+	return proposed
+}
+
+func (e *Estimate) Print(printer logging.Printer) {
+	printer.Printf(
+		"\nestimate from %s to %s: %d study days, %s available for %s of work\n",
+		e.StartDate.Format(LayoutDateOnly),
+		e.EndDate.Format(LayoutDateOnly),
+		e.StudyDays,
+		e.Capacity,
+		e.Workload,
+	)
+	for _, de := range e.Disciplines {
+		printer.Printf(
+			"- %s: %s of work, daily limit from %s to %s\n",
+			de.Discipline.Name,
+			de.Workload,
+			de.Discipline.DailyLimit,
+			de.DailyLimit,
+		)
+	}
+
+	if e.MissingPerStudyDay > 0 {
+		printer.Printf("- the hour grade needs about %s more per study day to fit everything\n", e.MissingPerStudyDay)
+	}
+}
+
+func roundUpDuration(duration time.Duration, precision time.Duration) time.Duration {
+	rounded := duration.Truncate(precision)
+	if rounded < duration {
+		rounded += precision
+	}
+
+	return rounded
+}
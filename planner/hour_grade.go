@@ -1,6 +1,7 @@
 package planner
 
 import (
+	"fmt"
 	"sort"
 	"strings"
 	"time"
@@ -12,91 +13,200 @@ const (
 	LayoutDateOnly         = "2006-01-02"
 )
 
-type HourGrade map[time.Weekday][]*HourGradeInterval
+var layouts12h = []string{"3:04PM", "3PM"}
 
-func NewHourGrade() HourGrade {
-	hg := HourGrade{}
+type HourGrade struct {
+	Weekdays  map[time.Weekday][]*HourGradeInterval
+	Blackouts []*Blackout
+	Overrides []*HourGradeOverride
+}
+
+func NewHourGrade() *HourGrade {
+	hg := &HourGrade{
+		Weekdays:  map[time.Weekday][]*HourGradeInterval{},
+		Blackouts: make([]*Blackout, 0),
+		Overrides: make([]*HourGradeOverride, 0),
+	}
 	for weekday := 0; weekday < 7; weekday++ {
-		hg[time.Weekday(weekday)] = make([]*HourGradeInterval, 0)
+		hg.Weekdays[time.Weekday(weekday)] = make([]*HourGradeInterval, 0)
 	}
 
 	return hg
 }
 
-func (hg HourGrade) Add(weekday time.Weekday, start time.Time, end time.Time) {
+func (hg *HourGrade) Add(weekday time.Weekday, start time.Time, end time.Time) {
+	hg.Weekdays[weekday] = addInterval(hg.Weekdays[weekday], start, end)
+}
+
+func addInterval(intervals []*HourGradeInterval, start time.Time, end time.Time) []*HourGradeInterval {
 	var extendedInterval *HourGradeInterval = nil
-	for index, hgi := range hg[weekday] {
+	for index, hgi := range intervals {
 		if hgi.Extends(start, end) {
-			hg[weekday] = append(hg[weekday][:index], hg[weekday][index+1:]...)
+			intervals = append(intervals[:index], intervals[index+1:]...)
 			extendedInterval = hgi
 			break
 		}
 	}
 
 	if extendedInterval != nil {
-		hg.Add(weekday, extendedInterval.Start, extendedInterval.End)
-		return
+		return addInterval(intervals, extendedInterval.Start, extendedInterval.End)
 	}
 
-	hg[weekday] = append(hg[weekday], &HourGradeInterval{Start: start, End: end})
+	return append(intervals, &HourGradeInterval{Start: start, End: end})
 }
 
-func (hg HourGrade) Sort() {
-	for _, intervals := range hg {
-		if len(intervals) == 0 {
+func (hg *HourGrade) AddOverrides(overrides ...*HourGradeOverride) {
+	hg.Overrides = append(hg.Overrides, overrides...)
+}
+
+// templateFor returns the intervals of the last override matching the date,
+// falling back to the weekly grade.
+func (hg *HourGrade) templateFor(date time.Time) []*HourGradeInterval {
+	for index := len(hg.Overrides) - 1; index >= 0; index-- {
+		if hg.Overrides[index].Matches(date) {
+			return hg.Overrides[index].Intervals
+		}
+	}
+
+	return hg.Weekdays[date.Weekday()]
+}
+
+func (hg *HourGrade) AddBlackouts(blackouts ...*Blackout) {
+	hg.Blackouts = append(hg.Blackouts, blackouts...)
+}
+
+func (hg *HourGrade) BlackoutFor(date time.Time) *Blackout {
+	for _, blackout := range hg.Blackouts {
+		if blackout.Contains(date) {
+			return blackout
+		}
+	}
+
+	return nil
+}
+
+// SkippedDates lists the dates between from and to (inclusive) that would have
+// intervals on the weekly grade, but were blacked out.
+func (hg *HourGrade) SkippedDates(from time.Time, to time.Time) []SkippedDate {
+	skipped := make([]SkippedDate, 0)
+	for date := from; !dateOnly(date).After(dateOnly(to)); date = date.AddDate(0, 0, 1) {
+		if len(hg.templateFor(date)) == 0 {
 			continue
 		}
 
-		sort.Slice(intervals, func(i, j int) bool {
-			return !intervals[i].Start.After(intervals[j].End)
-		})
+		if blackout := hg.BlackoutFor(date); blackout != nil {
+			skipped = append(skipped, SkippedDate{Date: dateOnly(date), Reason: blackout.Reason})
+		}
 	}
+
+	return skipped
 }
 
-func (hg HourGrade) HasGradeFor(date time.Time) bool {
-	return len(hg[date.Weekday()]) > 0
+func (hg *HourGrade) Sort() {
+	for _, intervals := range hg.Weekdays {
+		sortIntervals(intervals)
+	}
 }
 
-func (hg HourGrade) NextDate(from time.Time) (time.Time, error) {
-	// clone to be able to check if we come back to the same weekday
-	date := from.Add(0)
-	for {
-		date = date.AddDate(0, 0, 1)
-		if hg.HasGradeFor(date) {
-			return date, nil
-		}
+func sortIntervals(intervals []*HourGradeInterval) {
+	if len(intervals) == 0 {
+		return
+	}
 
-		if date.Weekday() == from.Weekday() {
-			break
+	sort.Slice(intervals, func(i, j int) bool {
+		return !intervals[i].Start.After(intervals[j].End)
+	})
+}
+
+func (hg *HourGrade) LongestInterval() time.Duration {
+	var longest time.Duration = 0
+	grades := make([][]*HourGradeInterval, 0, len(hg.Weekdays)+len(hg.Overrides))
+	for _, intervals := range hg.Weekdays {
+		grades = append(grades, intervals)
+	}
+
+	for _, override := range hg.Overrides {
+		grades = append(grades, override.Intervals)
+	}
+
+	for _, intervals := range grades {
+		for _, hgi := range intervals {
+			if duration := hgi.End.Sub(hgi.Start); duration > longest {
+				longest = duration
+			}
 		}
 	}
 
-	return time.Time{}, ErrUnavailableWeekdays
+	return longest
+}
+
+func (hg *HourGrade) HasGradeFor(date time.Time) bool {
+	return len(hg.templateFor(date)) > 0 && hg.BlackoutFor(date) == nil
 }
 
-func (hg HourGrade) IntervalsFor(date time.Time) ([]*HourGradeInterval, error) {
-	intervals := make([]*HourGradeInterval, len(hg[date.Weekday()]))
-	for index, hgi := range hg[date.Weekday()] {
-		start, err := hgi.SetStartTime(date)
-		if err != nil {
-			return nil, err
+// steadyFrom returns the date from which on only the weekly grade is used, a
+// whole week after the last blackout or override, so every date after it
+// repeats one of the week before.
+func (hg *HourGrade) steadyFrom(from time.Time) time.Time {
+	limit := from.AddDate(0, 0, 7)
+	for _, blackout := range hg.Blackouts {
+		if blackoutLimit := blackout.End.AddDate(0, 0, 7); blackoutLimit.After(limit) {
+			limit = blackoutLimit
+		}
+	}
+
+	for _, override := range hg.Overrides {
+		if overrideLimit := override.End.AddDate(0, 0, 7); overrideLimit.After(limit) {
+			limit = overrideLimit
 		}
+	}
+
+	return limit
+}
 
-		end, err := hgi.SetEndTime(date)
-		if err != nil {
-			return nil, err
+func (hg *HourGrade) NextDate(from time.Time) (time.Time, error) {
+	// a whole week after the last blackout or override without grade means there's nothing else to find
+	limit := hg.steadyFrom(from)
+	for date := from.AddDate(0, 0, 1); !date.After(limit); date = date.AddDate(0, 0, 1) {
+		if hg.HasGradeFor(date) {
+			return date, nil
 		}
+	}
 
+	return time.Time{}, ErrUnavailableWeekdays
+}
+
+func (hg *HourGrade) IntervalsFor(date time.Time) []*HourGradeInterval {
+	if hg.BlackoutFor(date) != nil {
+		return make([]*HourGradeInterval, 0)
+	}
+
+	template := hg.templateFor(date)
+	intervals := make([]*HourGradeInterval, len(template))
+	for index, hgi := range template {
 		intervals[index] = &HourGradeInterval{
-			Start: start,
-			End:   end,
+			Start: hgi.SetStartTime(date),
+			End:   hgi.SetEndTime(date),
 		}
 	}
 
-	return intervals, nil
+	return intervals
+}
+
+func (hg *HourGrade) CapacityFor(date time.Time) time.Duration {
+	return intervalsCapacity(hg.IntervalsFor(date))
+}
+
+func intervalsCapacity(intervals []*HourGradeInterval) time.Duration {
+	var capacity time.Duration = 0
+	for _, hgi := range intervals {
+		capacity += hgi.End.Sub(hgi.Start)
+	}
+
+	return capacity
 }
 
-func NewHourGradeFromRow(records [][]string) (HourGrade, error) {
+func NewHourGradeFromRow(records [][]string) (*HourGrade, error) {
 	if len(records) < 8 {
 		return nil, ErrUnexpectedGradeLength
 	}
@@ -110,17 +220,7 @@ func NewHourGradeFromRow(records [][]string) (HourGrade, error) {
 				break
 			}
 
-			entryData := strings.Split(entry, "-")
-			if len(entryData) != 2 {
-				return nil, ErrUnexpectedIntervalLength
-			}
-
-			start, err := time.Parse(LayoutTimeOnly, entryData[0])
-			if err != nil {
-				return nil, err
-			}
-
-			end, err := time.Parse(LayoutTimeOnly, entryData[1])
+			start, end, err := parseInterval(entry)
 			if err != nil {
 				return nil, err
 			}
@@ -132,3 +232,45 @@ func NewHourGradeFromRow(records [][]string) (HourGrade, error) {
 	hg.Sort()
 	return hg, nil
 }
+
+// parseInterval reads hh:mm-hh:mm or the 12-hour clock, like 7:30pm-9pm. When
+// the end is before the start, the interval crosses midnight and ends on the next day.
+func parseInterval(entry string) (time.Time, time.Time, error) {
+	entryData := strings.Split(entry, "-")
+	if len(entryData) != 2 {
+		return time.Time{}, time.Time{}, ErrUnexpectedIntervalLength
+	}
+
+	start, err := parseClock(entryData[0])
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	end, err := parseClock(entryData[1])
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	if end.Before(start) {
+		end = end.Add(24 * time.Hour)
+	}
+
+	return start, end, nil
+}
+
+func parseClock(value string) (time.Time, error) {
+	normalized := strings.ToUpper(strings.ReplaceAll(value, " ", ""))
+	layouts := []string{LayoutTimeOnly}
+	if strings.HasSuffix(normalized, "AM") || strings.HasSuffix(normalized, "PM") {
+		layouts = layouts12h
+	}
+
+	for _, layout := range layouts {
+		clock, err := time.Parse(layout, normalized)
+		if err == nil {
+			return clock, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidClockTime, value)
+}
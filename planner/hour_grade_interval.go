@@ -1,9 +1,6 @@
 package planner
 
-import (
-	"fmt"
-	"time"
-)
+import "time"
 
 type HourGradeInterval struct {
 	Start time.Time
@@ -25,24 +22,26 @@ func (hgi *HourGradeInterval) Extends(start time.Time, end time.Time) bool {
 	return extended
 }
 
-func (hgi *HourGradeInterval) SetStartTime(date time.Time) (time.Time, error) {
-	return time.Parse(
-		time.RFC3339,
-		fmt.Sprintf(
-			"%sT%s",
-			date.Format(LayoutDateOnly),
-			hgi.Start.Format(LayoutTimeWithTimezone),
-		),
-	)
+func (hgi *HourGradeInterval) SetStartTime(date time.Time) time.Time {
+	return onDate(date, hgi.Start)
 }
 
-func (hgi *HourGradeInterval) SetEndTime(date time.Time) (time.Time, error) {
-	return time.Parse(
-		time.RFC3339,
-		fmt.Sprintf(
-			"%sT%s",
-			date.Format(LayoutDateOnly),
-			hgi.End.Format(LayoutTimeWithTimezone),
-		),
-	)
+// SetEndTime may return a time on the next date, when the interval crosses midnight.
+func (hgi *HourGradeInterval) SetEndTime(date time.Time) time.Time {
+	return onDate(date, hgi.End)
+}
+
+// onDate moves the clock to the date. The clocks read by parseInterval are on
+// the first day of year 0, or on the second one for the ends after midnight,
+// so the offset from that first midnight may be more than a day.
+func onDate(date time.Time, clock time.Time) time.Time {
+	origin := time.Date(0, 1, 1, 0, 0, 0, 0, clock.Location())
+	if clock.Year() != 0 {
+		// intervals made on other dates only keep their clock
+		clockYear, clockMonth, clockDay := clock.Date()
+		origin = time.Date(clockYear, clockMonth, clockDay, 0, 0, 0, 0, clock.Location())
+	}
+
+	year, month, day := date.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, clock.Location()).Add(clock.Sub(origin))
 }
@@ -0,0 +1,78 @@
+package planner
+
+import "time"
+
+type HourGradeOverride struct {
+	Start     time.Time
+	End       time.Time
+	Weekdays  []time.Weekday
+	Intervals []*HourGradeInterval
+}
+
+func (hgo *HourGradeOverride) Matches(date time.Time) bool {
+	day := dateOnly(date)
+	if day.Before(hgo.Start) || day.After(hgo.End) {
+		return false
+	}
+
+	return len(hgo.Weekdays) == 0 || hasWeekday(hgo.Weekdays, date.Weekday())
+}
+
+func NewHourGradeOverridesFromRows(rows [][]string) ([]*HourGradeOverride, error) {
+	overrides := make([]*HourGradeOverride, 0)
+	for line := 1; line < len(rows); line++ {
+		columns := rows[line]
+		if len(columns) == 0 || columns[0] == "" {
+			break
+		}
+		// Start Date, End Date, Weekdays, Interval 1, Interval 2, ...
+		if len(columns) < 3 {
+			return nil, ErrUnexpectedColumnsLength
+		}
+
+		start, err := time.Parse(LayoutDateOnly, columns[0])
+		if err != nil {
+			return nil, err
+		}
+
+		override := &HourGradeOverride{
+			Start:     start,
+			End:       start,
+			Intervals: make([]*HourGradeInterval, 0),
+		}
+		if columns[1] != "" {
+			override.End, err = time.Parse(LayoutDateOnly, columns[1])
+			if err != nil {
+				return nil, err
+			}
+
+			if override.End.Before(override.Start) {
+				return nil, ErrInvalidDateRange
+			}
+		}
+
+		override.Weekdays, err = parseWeekdays(columns[2])
+		if err != nil {
+			return nil, err
+		}
+
+		for columnIndex := 3; columnIndex < len(columns); columnIndex++ {
+			entry := columns[columnIndex]
+			if entry == "" {
+				break
+			}
+
+			start, end, err := parseInterval(entry)
+			if err != nil {
+				return nil, err
+			}
+
+			override.Intervals = addInterval(override.Intervals, start, end)
+		}
+
+		sortIntervals(override.Intervals)
+		overrides = append(overrides, override)
+	}
+
+	return overrides, nil
+}
@@ -0,0 +1,145 @@
+package planner_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaiquegarcia/gostudy/v2/planner"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_HourGrade_Overrides(t *testing.T) {
+	weekRows := [][]string{
+		{"Day of Week", "Interval 1"},
+		{"SUNDAY", ""},
+		{"MONDAY", "14:00-17:00"},
+		{"TUESDAY", ""},
+		{"WEDNESDAY", "14:00-17:00"},
+		{"THURSDAY", ""},
+		{"FRIDAY", "14:00-17:00"},
+		{"SATURDAY", ""},
+	}
+	overrideRows := [][]string{
+		{"Start Date", "End Date", "Weekdays", "Interval 1", "Interval 2"},
+		{"2024-02-20", "", "", "08:00-12:00", "14:00-18:00"},
+		{"2024-03-01", "2024-03-31", "mon;wed", "19:00-20:00", ""},
+	}
+
+	hg, err := planner.NewHourGradeFromRow(weekRows)
+	if !assert.Nil(t, err, "err from NewHourGradeFromRow should be nil") {
+		t.FailNow()
+	}
+
+	overrides, err := planner.NewHourGradeOverridesFromRows(overrideRows)
+	if !assert.Nil(t, err, "err from NewHourGradeOverridesFromRows should be nil") {
+		t.FailNow()
+	}
+	hg.AddOverrides(overrides...)
+
+	t.Run("IntervalsFor should use the override of a single date", func(t *testing.T) {
+		// Arrange
+		date := time.Date(2024, 2, 20, 0, 0, 0, 0, time.UTC)
+
+		// Act
+		intervals := hg.IntervalsFor(date)
+
+		// Assert
+		if !assert.Len(t, intervals, 2, "should have 2 intervals") {
+			t.FailNow()
+		}
+		assert.Equal(t, "2024-02-20T08:00:00Z", intervals[0].Start.Format(time.RFC3339), "first interval should start at 08:00")
+		assert.Equal(t, "2024-02-20T18:00:00Z", intervals[1].End.Format(time.RFC3339), "second interval should end at 18:00")
+	})
+
+	t.Run("IntervalsFor should use the override of a range only on its weekdays", func(t *testing.T) {
+		// Arrange
+		monday := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+		friday := time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC)
+
+		// Act
+		mondayIntervals := hg.IntervalsFor(monday)
+		fridayIntervals := hg.IntervalsFor(friday)
+
+		// Assert
+		if !assert.Len(t, mondayIntervals, 1, "monday should have 1 interval") || !assert.Len(t, fridayIntervals, 1, "friday should have 1 interval") {
+			t.FailNow()
+		}
+		assert.Equal(t, "19:00", mondayIntervals[0].Start.Format(planner.LayoutTimeOnly), "monday should use the override")
+		assert.Equal(t, "14:00", fridayIntervals[0].Start.Format(planner.LayoutTimeOnly), "friday should fall back to the weekly grade")
+	})
+
+	t.Run("NextDate should find dates only available on overrides", func(t *testing.T) {
+		// Arrange
+		monday := time.Date(2024, 2, 19, 0, 0, 0, 0, time.UTC)
+
+		// Act
+		date, err := hg.NextDate(monday)
+
+		// Assert
+		assert.Nil(t, err, "err from NextDate should be nil")
+		assert.Equal(t, "2024-02-20", date.Format(planner.LayoutDateOnly), "next date should be the overridden tuesday")
+	})
+}
+
+func Test_HourGrade_ClockInput(t *testing.T) {
+	rows := [][]string{
+		{"Day of Week", "Interval 1", "Interval 2"},
+		{"SUNDAY", ""},
+		{"MONDAY", "22:00-01:00"},
+		{"TUESDAY", "7:30pm-9pm", "11 PM-12am"},
+		{"WEDNESDAY", ""},
+		{"THURSDAY", ""},
+		{"FRIDAY", ""},
+		{"SATURDAY", ""},
+	}
+
+	hg, err := planner.NewHourGradeFromRow(rows)
+	if !assert.Nil(t, err, "err from NewHourGradeFromRow should be nil") {
+		t.FailNow()
+	}
+
+	t.Run("IntervalsFor should end overnight intervals on the next date", func(t *testing.T) {
+		// Arrange
+		monday := time.Date(2024, 2, 19, 0, 0, 0, 0, time.UTC)
+
+		// Act
+		intervals := hg.IntervalsFor(monday)
+		capacity := hg.CapacityFor(monday)
+
+		// Assert
+		if !assert.Len(t, intervals, 1, "should have 1 interval") {
+			t.FailNow()
+		}
+		assert.Equal(t, "2024-02-19T22:00:00Z", intervals[0].Start.Format(time.RFC3339))
+		assert.Equal(t, "2024-02-20T01:00:00Z", intervals[0].End.Format(time.RFC3339))
+		assert.Equal(t, 3*time.Hour, capacity)
+	})
+
+	t.Run("IntervalsFor should read the 12-hour clock", func(t *testing.T) {
+		// Arrange
+		tuesday := time.Date(2024, 2, 20, 0, 0, 0, 0, time.UTC)
+
+		// Act
+		intervals := hg.IntervalsFor(tuesday)
+
+		// Assert
+		if !assert.Len(t, intervals, 2, "should have 2 intervals") {
+			t.FailNow()
+		}
+		assert.Equal(t, "2024-02-20T19:30:00Z", intervals[0].Start.Format(time.RFC3339))
+		assert.Equal(t, "2024-02-20T21:00:00Z", intervals[0].End.Format(time.RFC3339))
+		assert.Equal(t, "2024-02-21T00:00:00Z", intervals[1].End.Format(time.RFC3339), "12am should be midnight")
+	})
+
+	t.Run("NewHourGradeFromRow should quote invalid times", func(t *testing.T) {
+		// Arrange
+		invalid := [][]string{{"Day of Week"}, {"SUNDAY", "25:00-26:00"}, {"MONDAY"}, {"TUESDAY"}, {"WEDNESDAY"}, {"THURSDAY"}, {"FRIDAY"}, {"SATURDAY"}}
+
+		// Act
+		_, err := planner.NewHourGradeFromRow(invalid)
+
+		// Assert
+		assert.ErrorIs(t, err, planner.ErrInvalidClockTime)
+		assert.ErrorContains(t, err, `"25:00"`)
+	})
+}
@@ -0,0 +1,38 @@
+package planner
+
+import "github.com/kaiquegarcia/gostudy/v2/stream"
+
+type inputSettings struct {
+	shortMode  DurationMode
+	csvOptions []stream.CSVOption
+}
+
+// InputOption changes how the inputs are read, like the durations and the contents files.
+type InputOption func(settings *inputSettings)
+
+func newInputSettings(options []InputOption) *inputSettings {
+	settings := &inputSettings{
+		shortMode:  DurationModeHoursMinutes,
+		csvOptions: make([]stream.CSVOption, 0),
+	}
+	for _, option := range options {
+		option(settings)
+	}
+
+	return settings
+}
+
+// WithShortDurationMode reads the durations with only two pieces on the mode,
+// instead of DurationModeHoursMinutes.
+func WithShortDurationMode(mode DurationMode) InputOption {
+	return func(settings *inputSettings) {
+		settings.shortMode = mode
+	}
+}
+
+// WithCSVOptions opens the CSV contents files with the options, like stream.WithDelimiter.
+func WithCSVOptions(options ...stream.CSVOption) InputOption {
+	return func(settings *inputSettings) {
+		settings.csvOptions = append(settings.csvOptions, options...)
+	}
+}
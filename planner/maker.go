@@ -1,7 +1,6 @@
 package planner
 
 import (
-	"encoding/csv"
 	"os"
 	"time"
 
@@ -10,34 +9,99 @@ import (
 )
 
 type Maker struct {
-	outputFile                   *os.File
-	outputWriter                 *csv.Writer
-	hg                           HourGrade
+	sink                         OutputSink
+	hg                           *HourGrade
 	disciplines                  []*Discipline
 	inputedStartDate             time.Time
 	logger                       logging.Logger
 	checkedDisciplinesCount      int
 	currentDisciplineIndex       int
 	currentDayDisciplineDuration time.Duration
+	currentDate                  time.Time
 	finishedDisciplinesIndexes   []int
+	reviews                      []*review
+	strategy                     SelectionStrategy
+	checkedDisciplines           []bool
+	lastScheduledAt              []time.Time
+	remainingWork                []time.Duration
+	deadlineCapacity             []time.Duration
+	lateWork                     []time.Duration
+	deadlineMisses               []DeadlineMiss
+	openSplits                   int
+	outputBuffer                 []Output
+	placedContents               map[string]bool
+	blockedDisciplines           []bool
+	dryRun                       bool
+	report                       *Report
+	currentWeek                  time.Time
+	weekDuration                 []time.Duration
+	breakPolicy                  *BreakPolicy
+	progress                     *Progress
+	packingWindow                int
+	// end of the last interval, which may be on the next date when it crosses midnight
+	lastIntervalEnd time.Time
+	// first time window opening later on the interval, of the disciplines skipped because of it
+	windowOpening time.Time
 }
 
 func NewMaker(
 	logger logging.Logger,
-	hg HourGrade,
+	hg *HourGrade,
 	data []*Discipline,
 	startDate time.Time,
 	outputFilename string,
+	opts ...MakerOption,
 ) (*Maker, error) {
 	file, err := os.Create(outputFilename)
 	if err != nil {
 		return nil, err
 	}
 
-	cw := csv.NewWriter(file)
-	cw.Write([]string{"Datetime", "Discipline", "Subject", "Title", "Reference", "Duration"})
-	cw.Flush()
-	return &Maker{
+	sink, err := NewCSVOutputSink(file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return newMaker(logger, hg, data, startDate, sink, opts...), nil
+}
+
+// NewMakerWithSink sends the outputs to the sink instead of a CSV file. The
+// sink is closed along with the maker.
+func NewMakerWithSink(
+	logger logging.Logger,
+	hg *HourGrade,
+	data []*Discipline,
+	startDate time.Time,
+	sink OutputSink,
+	opts ...MakerOption,
+) *Maker {
+	return newMaker(logger, hg, data, startDate, sink, opts...)
+}
+
+// NewDryRunMaker simulates the whole plan in memory, without writing any output,
+// skipping the contents that can't be placed so they can be reported later.
+func NewDryRunMaker(
+	logger logging.Logger,
+	hg *HourGrade,
+	data []*Discipline,
+	startDate time.Time,
+	opts ...MakerOption,
+) *Maker {
+	maker := newMaker(logger, hg, data, startDate, discardOutputSink{}, opts...)
+	maker.dryRun = true
+	return maker
+}
+
+func newMaker(
+	logger logging.Logger,
+	hg *HourGrade,
+	data []*Discipline,
+	startDate time.Time,
+	sink OutputSink,
+	opts ...MakerOption,
+) *Maker {
+	maker := &Maker{
 		hg:                         hg,
 		disciplines:                data,
 		inputedStartDate:           startDate,
@@ -45,17 +109,51 @@ func NewMaker(
 		currentDisciplineIndex:     -1,
 		checkedDisciplinesCount:    0,
 		finishedDisciplinesIndexes: make([]int, 0),
-		outputFile:                 file,
-		outputWriter:               cw,
-	}, nil
+		reviews:                    make([]*review, 0),
+		strategy:                   &roundRobinStrategy{},
+		checkedDisciplines:         make([]bool, len(data)),
+		lastScheduledAt:            make([]time.Time, len(data)),
+		remainingWork:              make([]time.Duration, len(data)),
+		deadlineCapacity:           make([]time.Duration, len(data)),
+		lateWork:                   make([]time.Duration, len(data)),
+		deadlineMisses:             make([]DeadlineMiss, 0),
+		outputBuffer:               make([]Output, 0),
+		placedContents:             map[string]bool{},
+		blockedDisciplines:         make([]bool, len(data)),
+		weekDuration:               make([]time.Duration, len(data)),
+		sink:                       sink,
+		report:                     newReport(startDate, data),
+	}
+
+	for _, opt := range opts {
+		opt(maker)
+	}
+
+	return maker
 }
 
-func (p *Maker) Close() {
-	p.outputWriter.Flush()
-	p.outputFile.Close()
+func (p *Maker) Report() *Report {
+	return p.report
+}
+
+// Close writes the outputs still held, then closes the sink and the
+// disciplines, returning the first error. Some sinks only write their ending
+// on Close, so the plan may be incomplete when it fails.
+func (p *Maker) Close() error {
+	firstErr := p.flushOutputs()
+	err := p.sink.Close()
+	if err != nil && firstErr == nil {
+		firstErr = err
+	}
+
 	for _, d := range p.disciplines {
-		d.Close()
+		err = d.Close()
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
 	}
+
+	return firstErr
 }
 
 func (p *Maker) hasExploredAllDisciplines() bool {
@@ -76,17 +174,41 @@ func (p *Maker) isDisciplineFinished(disciplineIndex int) bool {
 	return false
 }
 
+// finishIfExhausted finishes the discipline as soon as its last content is
+// placed, as a discipline restricted to some dates may never have another
+// turn to find it out.
+func (p *Maker) finishIfExhausted(disciplineIndex int) error {
+	if p.isDisciplineFinished(disciplineIndex) {
+		return nil
+	}
+
+	next, err := p.disciplines[disciplineIndex].Lookahead(1)
+	if err != nil {
+		return err
+	}
+
+	if len(next) == 0 {
+		p.finishedDisciplinesIndexes = append(p.finishedDisciplinesIndexes, disciplineIndex)
+	}
+
+	return nil
+}
+
 func (p *Maker) nextDiscipline(hgi *HourGradeInterval) {
+	p.leaveDiscipline(hgi)
+	p.selectDiscipline()
+}
+
+func (p *Maker) leaveDiscipline(hgi *HourGradeInterval) {
 	if p.currentDayDisciplineDuration > 0 {
 		gap := p.disciplines[p.currentDisciplineIndex].SubjectGap
 		p.logger.Debug("discipline has duration > 0, adding subject gap of %s", gap)
 		hgi.Start = hgi.Start.Add(gap)
 	}
 
-	p.currentDisciplineIndex++
-	p.currentDisciplineIndex %= len(p.disciplines)
-	p.currentDayDisciplineDuration = 0
+	p.checkedDisciplines[p.currentDisciplineIndex] = true
 	p.checkedDisciplinesCount++
+	p.currentDayDisciplineDuration = 0
 }
 
 func (p *Maker) startDate() (time.Time, error) {
@@ -115,11 +237,45 @@ func (p *Maker) Mount() error {
 		return err
 	}
 
+	p.report.StartDate = date
+
+	p.logger.Debug("calculating the workload of each discipline")
+	err = p.loadRemainingWork()
+	if err != nil {
+		return err
+	}
+
+	for index, workload := range p.remainingWork {
+		p.report.Disciplines[index].Workload = workload
+	}
+
+	p.logger.Debug("checking if every discipline has intervals to take")
+	err = p.checkAllowedSlots(date)
+	if err != nil {
+		return err
+	}
+
+	p.logger.Debug("checking if the hour grade is enough to reach the disciplines deadlines")
+	err = p.loadDeadlineCapacity(date)
+	if err == ErrDeadlineMissed && p.dryRun {
+		p.logger.Debug("deadlines will be missed, but the simulation goes on to report by how much")
+		p.deadlineMisses = p.deadlineMisses[:0]
+	} else if err != nil {
+		p.logger.Error(err, "the hour grade is not enough to reach the disciplines deadlines")
+		return err
+	}
+
 	p.currentDisciplineIndex = 0
 	p.logger.Debug("starting mount loop")
 	for {
 		err = p.mountDate(date)
 		if err == stream.ErrEOF {
+			p.report.EndDate = p.currentDate
+			p.report.Skipped = p.hg.SkippedDates(p.report.StartDate, p.report.EndDate)
+			for _, skipped := range p.report.Skipped {
+				p.logger.Info("skipped %s: %s", skipped.Date.Format(LayoutDateOnly), skipped.Reason)
+			}
+
 			break
 		}
 
@@ -137,5 +293,8 @@ func (p *Maker) Mount() error {
 		p.logger.Debug("next date retrieved successfully: %s, moving to the loop", date.Format(LayoutDateOnly))
 	}
 
-	return nil
+	p.logger.Debug("checking if every discipline finished before its deadline")
+	err = p.checkDeadlines()
+	p.report.DeadlineMisses = p.deadlineMisses
+	return err
 }
@@ -0,0 +1,129 @@
+package planner
+
+import "time"
+
+// allowedUntil tells if the discipline may take the current position of the
+// interval and until when, following its weekdays and time windows.
+func (p *Maker) allowedUntil(discipline *Discipline, hgi *HourGradeInterval) (time.Time, bool) {
+	if len(discipline.Weekdays) > 0 && !hasWeekday(discipline.Weekdays, p.currentDate.Weekday()) {
+		return time.Time{}, false
+	}
+
+	if len(discipline.TimeWindows) == 0 {
+		return hgi.End, true
+	}
+
+	for _, window := range windowsAround(discipline, p.currentDate) {
+		if hgi.Start.Before(window.Start) || !hgi.Start.Before(window.End) {
+			continue
+		}
+
+		if window.End.After(hgi.End) {
+			return hgi.End, true
+		}
+
+		return window.End, true
+	}
+
+	return time.Time{}, false
+}
+
+// opensAt returns when the first time window of the discipline opens later on
+// the interval, or zero when none does.
+func (p *Maker) opensAt(discipline *Discipline, hgi *HourGradeInterval) time.Time {
+	if len(discipline.Weekdays) > 0 && !hasWeekday(discipline.Weekdays, p.currentDate.Weekday()) {
+		return time.Time{}
+	}
+
+	var opening time.Time
+	for _, window := range windowsAround(discipline, p.currentDate) {
+		if !window.Start.After(hgi.Start) || !window.Start.Before(hgi.End) {
+			continue
+		}
+
+		if opening.IsZero() || window.Start.Before(opening) {
+			opening = window.Start
+		}
+	}
+
+	return opening
+}
+
+// windowsAround places the time windows of the discipline on the date and on
+// the dates around it, as overnight intervals and windows reach them.
+func windowsAround(discipline *Discipline, date time.Time) []*HourGradeInterval {
+	windows := make([]*HourGradeInterval, 0, 3*len(discipline.TimeWindows))
+	for _, window := range discipline.TimeWindows {
+		for days := -1; days <= 1; days++ {
+			day := date.AddDate(0, 0, days)
+			windows = append(windows, &HourGradeInterval{Start: window.SetStartTime(day), End: window.SetEndTime(day)})
+		}
+	}
+
+	return windows
+}
+
+// checkAllowedSlots makes sure every discipline restricted to some weekdays or
+// time windows has at least one interval to take from the start date on,
+// otherwise the plan would never end.
+func (p *Maker) checkAllowedSlots(from time.Time) error {
+	for _, discipline := range p.disciplines {
+		if len(discipline.Weekdays) == 0 && len(discipline.TimeWindows) == 0 {
+			continue
+		}
+
+		if !p.hasAllowedSlot(discipline, from) {
+			p.logger.Error(ErrDisciplineNeverAllowed, "discipline '%s' has no interval to take", discipline.Name)
+			return ErrDisciplineNeverAllowed
+		}
+	}
+
+	return nil
+}
+
+// hasAllowedSlot looks for the slot on the intervals each date will really
+// have, with the overrides and blackouts, until the weekly grade repeats itself.
+func (p *Maker) hasAllowedSlot(discipline *Discipline, from time.Time) bool {
+	limit := p.hg.steadyFrom(from)
+	for date := from; !date.After(limit); date = date.AddDate(0, 0, 1) {
+		if p.allowedCapacityFor(discipline, date) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// allowedCapacityFor is how long the intervals of the date are open to the
+// discipline, following its weekdays and time windows.
+func (p *Maker) allowedCapacityFor(discipline *Discipline, date time.Time) time.Duration {
+	if len(discipline.Weekdays) > 0 && !hasWeekday(discipline.Weekdays, date.Weekday()) {
+		return 0
+	}
+
+	intervals := p.hg.IntervalsFor(date)
+	if len(discipline.TimeWindows) == 0 {
+		return intervalsCapacity(intervals)
+	}
+
+	windows := windowsAround(discipline, date)
+	var capacity time.Duration = 0
+	for _, hgi := range intervals {
+		for _, window := range windows {
+			start, end := hgi.Start, hgi.End
+			if window.Start.After(start) {
+				start = window.Start
+			}
+
+			if window.End.Before(end) {
+				end = window.End
+			}
+
+			if start.Before(end) {
+				capacity += end.Sub(start)
+			}
+		}
+	}
+
+	return capacity
+}
@@ -0,0 +1,124 @@
+package planner_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaiquegarcia/gostudy/v2/planner"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Maker_AllowedSlots(t *testing.T) {
+	disciplineHeader := []string{"Name", "Filename", "Daily Limit", "Content Gap", "Subject Gap", "Weekdays", "Time Windows"}
+	contents := map[string][][]string{
+		"math.csv": {
+			{"Logic", "Sets", "01:00:00"},
+			{"Logic", "Relations", "01:00:00"},
+		},
+		"history.csv": {
+			{"Ancient", "Egypt", "01:00:00"},
+		},
+	}
+
+	t.Run("should wait for a time window opening in the middle of the interval", func(t *testing.T) {
+		// Arrange
+		hg := testHourGrade(t, "14:00-17:00")
+		disciplines := testDisciplines(t, [][]string{
+			disciplineHeader,
+			{"Math", "math.csv", "02:00:00", "00:00:00", "00:00:00", "", "15:00-17:00"},
+		}, contents)
+
+		// Act
+		outputs, err := mountOutputs(t, hg, disciplines)
+
+		// Assert
+		assert.Nil(t, err, "err from Mount should be nil")
+		assert.Equal(t, []string{
+			"2024-02-21 15:00 Math Sets",
+			"2024-02-21 16:00 Math Relations",
+		}, outputs)
+	})
+
+	t.Run("should give the discipline its turn once its window opens after others' contents", func(t *testing.T) {
+		// Arrange
+		hg := testHourGrade(t, "14:00-17:00")
+		disciplines := testDisciplines(t, [][]string{
+			disciplineHeader,
+			{"Math", "math.csv", "02:00:00", "00:00:00", "00:00:00", "", "15:30-17:00"},
+			{"History", "history.csv", "01:00:00", "00:00:00", "00:00:00", "", ""},
+		}, contents)
+
+		// Act
+		outputs, err := mountOutputs(t, hg, disciplines)
+
+		// Assert
+		assert.Nil(t, err, "err from Mount should be nil")
+		assert.Equal(t, []string{
+			"2024-02-21 14:00 History Egypt",
+			"2024-02-21 15:30 Math Sets",
+			"2024-02-22 15:30 Math Relations",
+		}, outputs)
+	})
+
+	t.Run("should end the simulation of a window opening in the middle of the interval", func(t *testing.T) {
+		// Arrange
+		hg := testHourGrade(t, "14:00-17:00")
+		disciplines := testDisciplines(t, [][]string{
+			disciplineHeader,
+			{"Math", "math.csv", "02:00:00", "00:00:00", "00:00:00", "", "15:00-17:00"},
+		}, contents)
+		maker := planner.NewDryRunMaker(testLogger(), hg, disciplines, testStartDate)
+
+		// Act
+		err := mountWithin(t, maker)
+		closeErr := maker.Close()
+
+		// Assert
+		assert.Nil(t, err, "err from Mount should be nil")
+		assert.Nil(t, closeErr, "err from Close should be nil")
+		assert.Empty(t, maker.Report().Disciplines[0].Unplayable, "should place both contents")
+		assert.Equal(t, "2024-02-21T17:00:00Z", maker.Report().Disciplines[0].FinishDate.Format(time.RFC3339))
+	})
+
+	t.Run("should find the slots on the hour grade overrides", func(t *testing.T) {
+		// Arrange
+		hg := testHourGrade(t, "08:00-10:00")
+		overrides, err := planner.NewHourGradeOverridesFromRows([][]string{
+			{"Start Date", "End Date", "Weekdays", "Interval 1"},
+			{"2024-02-22", "", "", "19:00-21:00"},
+		})
+		if !assert.Nil(t, err, "err from NewHourGradeOverridesFromRows should be nil") {
+			t.FailNow()
+		}
+		hg.AddOverrides(overrides...)
+		disciplines := testDisciplines(t, [][]string{
+			disciplineHeader,
+			{"Math", "math.csv", "02:00:00", "00:00:00", "00:00:00", "", "19:00-22:00"},
+		}, contents)
+
+		// Act
+		outputs, err := mountOutputs(t, hg, disciplines)
+
+		// Assert
+		assert.Nil(t, err, "err from Mount should be nil")
+		assert.Equal(t, []string{
+			"2024-02-22 19:00 Math Sets",
+			"2024-02-22 20:00 Math Relations",
+		}, outputs)
+	})
+
+	t.Run("should refuse disciplines without any slot to take", func(t *testing.T) {
+		// Arrange
+		hg := testHourGrade(t, "08:00-10:00")
+		disciplines := testDisciplines(t, [][]string{
+			disciplineHeader,
+			{"Math", "math.csv", "02:00:00", "00:00:00", "00:00:00", "", "19:00-22:00"},
+		}, contents)
+
+		// Act
+		_, err := mountOutputs(t, hg, disciplines)
+
+		// Assert
+		assert.ErrorIs(t, err, planner.ErrDisciplineNeverAllowed)
+	})
+}
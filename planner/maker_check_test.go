@@ -0,0 +1,79 @@
+package planner_test
+
+import (
+	"testing"
+
+	"github.com/kaiquegarcia/gostudy/v2/planner"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Maker_Check(t *testing.T) {
+	disciplineRows := [][]string{
+		{"Name", "Filename", "Daily Limit", "Content Gap", "Subject Gap"},
+		{"Math", "math.csv", "01:00:00", "00:00:00", "00:00:00"},
+	}
+
+	t.Run("the simulation should leave out and report the contents that can never fit", func(t *testing.T) {
+		// Arrange
+		hg := testHourGrade(t, "14:00-15:00")
+		disciplines := testDisciplines(t, disciplineRows, map[string][][]string{
+			"math.csv": {
+				{"Logic", "Sets", "00:30:00"},
+				{"Logic", "Relations", "02:00:00"},
+				{"Logic", "Functions", "00:30:00"},
+			},
+		})
+		maker := planner.NewDryRunMaker(testLogger(), hg, disciplines, testStartDate)
+		defer maker.Close()
+
+		// Act
+		err := mountWithin(t, maker)
+
+		// Assert
+		assert.Nil(t, err, "err from Mount should be nil")
+		report := maker.Report()
+		assert.True(t, report.HasProblems(), "the report should have problems")
+		if assert.Len(t, report.Disciplines[0].Unplayable, 1, "only relations can never fit") {
+			assert.Equal(t, "Relations", report.Disciplines[0].Unplayable[0].Title)
+		}
+		assert.Equal(t, "2024-02-21", report.EndDate.Format(planner.LayoutDateOnly), "the other contents should fit on the first day")
+	})
+
+	t.Run("the simulation should have no problems when everything fits", func(t *testing.T) {
+		// Arrange
+		hg := testHourGrade(t, "14:00-15:00")
+		disciplines := testDisciplines(t, disciplineRows, map[string][][]string{
+			"math.csv": {
+				{"Logic", "Sets", "00:30:00"},
+				{"Logic", "Functions", "00:30:00"},
+			},
+		})
+		maker := planner.NewDryRunMaker(testLogger(), hg, disciplines, testStartDate)
+		defer maker.Close()
+
+		// Act
+		err := mountWithin(t, maker)
+
+		// Assert
+		assert.Nil(t, err, "err from Mount should be nil")
+		assert.False(t, maker.Report().HasProblems(), "the report shouldn't have problems")
+		assert.Empty(t, maker.Report().Disciplines[0].Unplayable)
+	})
+
+	t.Run("the plan should fail on the contents that can never fit", func(t *testing.T) {
+		// Arrange
+		hg := testHourGrade(t, "14:00-15:00")
+		disciplines := testDisciplines(t, disciplineRows, map[string][][]string{
+			"math.csv": {
+				{"Logic", "Sets", "00:30:00"},
+				{"Logic", "Relations", "02:00:00"},
+			},
+		})
+
+		// Act
+		_, err := mountOutputs(t, hg, disciplines)
+
+		// Assert
+		assert.ErrorIs(t, err, planner.ErrContentDurationUnplayable)
+	})
+}
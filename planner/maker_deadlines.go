@@ -0,0 +1,129 @@
+package planner
+
+import "time"
+
+type DeadlineMiss struct {
+	Discipline   *Discipline
+	Deadline     time.Time
+	MissingHours time.Duration
+}
+
+func (p *Maker) DeadlineMisses() []DeadlineMiss {
+	return p.deadlineMisses
+}
+
+// the deadline day is available for study, so everything must end before the next one
+func deadlineEnd(discipline *Discipline) time.Time {
+	return discipline.Deadline.AddDate(0, 0, 1)
+}
+
+func isAfterDeadline(discipline *Discipline, t time.Time) bool {
+	return !discipline.Deadline.IsZero() && t.After(deadlineEnd(discipline))
+}
+
+func (p *Maker) loadDeadlineCapacity(startDate time.Time) error {
+	for index, discipline := range p.disciplines {
+		if discipline.Deadline.IsZero() {
+			continue
+		}
+
+		capacity := p.capacityUntilDeadline(index, startDate)
+		p.deadlineCapacity[index] = capacity
+		p.logger.Debug(
+			"discipline '%s' has %s of hour grade until its deadline and %s of content",
+			discipline.Name, capacity, p.remainingWork[index],
+		)
+		if capacity < p.remainingWork[index] {
+			p.deadlineMisses = append(p.deadlineMisses, DeadlineMiss{
+				Discipline:   discipline,
+				Deadline:     discipline.Deadline,
+				MissingHours: p.remainingWork[index] - capacity,
+			})
+		}
+	}
+
+	if len(p.deadlineMisses) > 0 {
+		return ErrDeadlineMissed
+	}
+
+	return nil
+}
+
+// updateDeadlineCapacity leaves only the capacity after the date, once it's over.
+func (p *Maker) updateDeadlineCapacity(date time.Time) {
+	for index, discipline := range p.disciplines {
+		if discipline.Deadline.IsZero() {
+			continue
+		}
+
+		p.deadlineCapacity[index] = p.capacityUntilDeadline(index, date.AddDate(0, 0, 1))
+	}
+}
+
+// capacityUntilDeadline is how long the discipline could study from the date
+// until its deadline if it had the hour grade to itself, following its weekdays,
+// time windows and limits. The other disciplines may still take part of it.
+func (p *Maker) capacityUntilDeadline(disciplineIndex int, from time.Time) time.Duration {
+	discipline := p.disciplines[disciplineIndex]
+	var (
+		capacity time.Duration = 0
+		week     time.Time
+		weekLeft time.Duration
+	)
+	for date := from; date.Before(deadlineEnd(discipline)); date = date.AddDate(0, 0, 1) {
+		dayCapacity := minDuration(p.allowedCapacityFor(discipline, date), discipline.DailyLimit)
+		if discipline.WeeklyLimit > 0 {
+			if start := weekStart(date); !start.Equal(week) {
+				week = start
+				weekLeft = discipline.WeeklyLimit
+				if week.Equal(p.currentWeek) {
+					// part of the current week may be taken already
+					weekLeft = maxDuration(weekLeft-p.weekDuration[disciplineIndex], 0)
+				}
+			}
+
+			dayCapacity = minDuration(dayCapacity, weekLeft)
+			weekLeft -= dayCapacity
+		}
+
+		capacity += dayCapacity
+	}
+
+	return capacity
+}
+
+func (p *Maker) checkDeadlines() error {
+	for index, discipline := range p.disciplines {
+		if p.lateWork[index] == 0 {
+			continue
+		}
+
+		p.deadlineMisses = append(p.deadlineMisses, DeadlineMiss{
+			Discipline:   discipline,
+			Deadline:     discipline.Deadline,
+			MissingHours: p.lateWork[index],
+		})
+	}
+
+	if len(p.deadlineMisses) > 0 {
+		return ErrDeadlineMissed
+	}
+
+	return nil
+}
+
+func minDuration(a time.Duration, b time.Duration) time.Duration {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+func maxDuration(a time.Duration, b time.Duration) time.Duration {
+	if a > b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,145 @@
+package planner_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaiquegarcia/gostudy/v2/planner"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Maker_Deadlines(t *testing.T) {
+	disciplineRows := [][]string{
+		{"Name", "Filename", "Daily Limit", "Content Gap", "Subject Gap", "Deadline"},
+		{"History", "history.csv", "01:00:00", "00:00:00", "00:00:00", ""},
+		{"Math", "math.csv", "01:00:00", "00:00:00", "00:00:00", "2024-02-22"},
+	}
+	contents := map[string][][]string{
+		"math.csv": {
+			{"Logic", "Sets", "01:00:00"},
+			{"Logic", "Relations", "01:00:00"},
+		},
+		"history.csv": {
+			{"Ancient", "Egypt", "01:00:00"},
+			{"Ancient", "Rome", "01:00:00"},
+		},
+	}
+
+	t.Run("should fail before placing anything when the hour grade can't reach the deadline", func(t *testing.T) {
+		// Arrange
+		hg := testHourGrade(t, "14:00-14:30")
+		disciplines := testDisciplines(t, disciplineRows, contents)
+		sink := planner.NewMemoryOutputSink()
+		maker := planner.NewMakerWithSink(testLogger(), hg, disciplines, testStartDate, sink)
+		defer maker.Close()
+
+		// Act
+		err := mountWithin(t, maker)
+
+		// Assert
+		assert.ErrorIs(t, err, planner.ErrDeadlineMissed)
+		assert.Empty(t, sink.Outputs, "nothing should be placed")
+		if assert.Len(t, maker.DeadlineMisses(), 1, "only math should miss its deadline") {
+			miss := maker.DeadlineMisses()[0]
+			assert.Equal(t, "Math", miss.Discipline.Name)
+			assert.Equal(t, time.Hour, miss.MissingHours, "math should miss 1 hour, as the deadline only leaves 2 slots of 30 minutes")
+		}
+	})
+
+	t.Run("should count only the hour grade the discipline may take before its deadline", func(t *testing.T) {
+		header := []string{"Name", "Filename", "Daily Limit", "Content Gap", "Subject Gap", "Deadline", "Weekdays", "Time Windows", "Weekly Limit"}
+		cases := map[string][]string{
+			"weekdays":     {"Math", "math.csv", "01:00:00", "00:00:00", "00:00:00", "2024-02-22", "thu", "", ""},
+			"time windows": {"Math", "math.csv", "01:00:00", "00:00:00", "00:00:00", "2024-02-22", "", "14:30-15:00", ""},
+			"weekly limit": {"Math", "math.csv", "01:00:00", "00:00:00", "00:00:00", "2024-02-25", "", "", "01:00:00"},
+		}
+		for name, row := range cases {
+			// Arrange
+			hg := testHourGrade(t, "14:00-15:00")
+			disciplines := testDisciplines(t, [][]string{header, row}, map[string][][]string{
+				"math.csv": {
+					{"Logic", "Sets", "01:00:00"},
+					{"Logic", "Relations", "01:00:00"},
+				},
+			})
+			sink := planner.NewMemoryOutputSink()
+			maker := planner.NewMakerWithSink(testLogger(), hg, disciplines, testStartDate, sink)
+
+			// Act
+			err := mountWithin(t, maker)
+			maker.Close()
+
+			// Assert
+			assert.ErrorIs(t, err, planner.ErrDeadlineMissed, "the %s should leave only 1 hour before the deadline", name)
+			assert.Empty(t, sink.Outputs, "nothing should be placed because of the %s", name)
+			if assert.Len(t, maker.DeadlineMisses(), 1, "math should miss its deadline because of the %s", name) {
+				assert.Equal(t, time.Hour, maker.DeadlineMisses()[0].MissingHours, "math should miss 1 hour because of the %s", name)
+			}
+		}
+	})
+
+	t.Run("should report the contents placed after the deadline", func(t *testing.T) {
+		// Arrange
+		hg := testHourGrade(t, "14:00-15:00")
+		disciplines := testDisciplines(t, disciplineRows, contents)
+		sink := planner.NewMemoryOutputSink()
+		maker := planner.NewMakerWithSink(testLogger(), hg, disciplines, testStartDate, sink)
+		defer maker.Close()
+
+		// Act
+		err := mountWithin(t, maker)
+
+		// Assert
+		assert.ErrorIs(t, err, planner.ErrDeadlineMissed)
+		assert.Equal(t, []string{
+			"2024-02-21 14:00 History Egypt",
+			"2024-02-22 14:00 History Rome",
+			"2024-02-23 14:00 Math Sets",
+			"2024-02-24 14:00 Math Relations",
+		}, formatOutputs(sink.Outputs))
+		if assert.Len(t, maker.Report().DeadlineMisses, 1, "the report should have the miss") {
+			assert.Equal(t, 2*time.Hour, maker.Report().DeadlineMisses[0].MissingHours, "both math contents end after the deadline")
+		}
+	})
+
+	t.Run("the deadline strategy should place the disciplines with deadlines first", func(t *testing.T) {
+		// Arrange
+		hg := testHourGrade(t, "14:00-15:00")
+		disciplines := testDisciplines(t, disciplineRows, contents)
+		factory, _ := planner.NewSelectionStrategyFactory(planner.StrategyDeadline)
+
+		// Act
+		outputs, err := mountOutputs(t, hg, disciplines, planner.WithSelectionStrategyFactory(factory))
+
+		// Assert
+		assert.Nil(t, err, "err from Mount should be nil")
+		assert.Equal(t, []string{
+			"2024-02-21 14:00 Math Sets",
+			"2024-02-22 14:00 Math Relations",
+			"2024-02-23 14:00 History Egypt",
+			"2024-02-24 14:00 History Rome",
+		}, outputs)
+	})
+
+	t.Run("the simulation should go on to report the deadlines missed", func(t *testing.T) {
+		// Arrange
+		hg := testHourGrade(t, "14:00-14:30")
+		disciplines := testDisciplines(t, disciplineRows, map[string][][]string{
+			"math.csv":    {{"Logic", "Sets", "00:30:00"}, {"Logic", "Relations", "00:30:00"}, {"Logic", "Functions", "00:30:00"}},
+			"history.csv": {{"Ancient", "Egypt", "00:30:00"}},
+		})
+		maker := planner.NewDryRunMaker(testLogger(), hg, disciplines, testStartDate)
+		defer maker.Close()
+
+		// Act
+		err := mountWithin(t, maker)
+
+		// Assert
+		assert.ErrorIs(t, err, planner.ErrDeadlineMissed)
+		report := maker.Report()
+		if assert.Len(t, report.DeadlineMisses, 1, "the report should have the miss") {
+			assert.Equal(t, "Math", report.DeadlineMisses[0].Discipline.Name)
+		}
+		assert.Equal(t, "2024-02-24", report.EndDate.Format(planner.LayoutDateOnly), "the simulation should place everything")
+	})
+}
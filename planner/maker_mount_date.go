@@ -3,22 +3,30 @@ package planner
 import "time"
 
 func (p *Maker) mountDate(date time.Time) error {
-	dateStr := date.Format(LayoutDateOnly)
-	p.logger.Debug("retrieving time intervals for date %s", dateStr)
-	intervals, err := p.hg.IntervalsFor(date)
-	if err != nil {
-		p.logger.Error(err, "could not retrieve time intervals for date %s", dateStr)
-		return err
-	}
-
+	p.logger.Debug("retrieving time intervals for date %s", date.Format(LayoutDateOnly))
+	intervals := p.hg.IntervalsFor(date)
+	p.currentDate = date
+	p.startWeek(date)
 	p.currentDayDisciplineDuration = 0
 	p.logger.Debug("%d intervals found, start loop", len(intervals))
 	for _, hgi := range intervals {
-		err = p.mountInterval(hgi)
+		if hgi.Start.Before(p.lastIntervalEnd) {
+			// the previous date's overnight interval is still running
+			hgi.Start = p.lastIntervalEnd
+		}
+
+		if !hgi.Start.Before(hgi.End) {
+			continue
+		}
+
+		err := p.mountInterval(hgi)
 		if err != nil {
 			return err
 		}
+
+		p.lastIntervalEnd = hgi.End
 	}
 
+	p.updateDeadlineCapacity(date)
 	return nil
 }
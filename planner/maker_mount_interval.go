@@ -10,12 +10,19 @@ func (p *Maker) mountInterval(hgi *HourGradeInterval) error {
 	initialStr := hgi.Start.Format(LayoutTimeOnly)
 	endStr := hgi.End.Format(LayoutTimeOnly)
 	p.logger.Debug("starting procedure for interval %s-%s", initialStr, endStr)
+	// hgi.Start moves forward as the contents are placed
+	interval := &HourGradeInterval{Start: hgi.Start, End: hgi.End}
 	var (
 		previousDiscipline *Discipline
 		previousSubject    string
 		isFirst            = true
+		// continuous study since the last break, for the break policy
+		focused time.Duration
+		breaks  int
 	)
-	p.checkedDisciplinesCount = 0
+	p.resetCheckedDisciplines()
+	p.selectDiscipline()
+	p.windowOpening = time.Time{}
 
 	loopCounter := 0
 	for {
@@ -24,7 +31,7 @@ func (p *Maker) mountInterval(hgi *HourGradeInterval) error {
 		p.logger.Debug("inner loop %d, checking if interval is still able to proceed", loopCounter)
 		if !hgi.Start.Before(hgi.End) {
 			p.logger.Debug("already reached the end of the interval, breaking at inner loop %d", loopCounter)
-			p.checkedDisciplinesCount = 0
+			p.resetCheckedDisciplines()
 			break
 		}
 
@@ -32,16 +39,32 @@ func (p *Maker) mountInterval(hgi *HourGradeInterval) error {
 			"inner loop %d still have time left (%s - %s), checking if already explored all possibilities",
 			loopCounter, startStr, endStr,
 		)
+		if p.hasExploredAllDisciplines() && !p.windowOpening.IsZero() {
+			// the disciplines skipped because of their time windows get another turn once they open
+			if p.windowOpening.After(hgi.Start) {
+				p.logger.Debug("waiting until %s, when a time window opens", p.windowOpening.Format(LayoutTimeOnly))
+				hgi.Start = p.windowOpening
+				isFirst = true
+				focused = 0
+			}
+
+			p.windowOpening = time.Time{}
+			p.resetCheckedDisciplines()
+			p.selectDiscipline()
+			continue
+		}
+
 		if p.hasExploredAllDisciplines() {
 			p.logger.Debug("already explored all possibilities, breaking at inner loop %d", loopCounter)
-			p.checkedDisciplinesCount = 0
+			p.resetCheckedDisciplines()
 			break
 		}
 
 		p.logger.Debug("still have disciplines to explore, checking current discipline index %d", p.currentDisciplineIndex)
 		discipline := p.disciplines[p.currentDisciplineIndex]
 		p.logger.Debug("which means '%s' ~ checking if it's already finished", discipline.Name)
-		if p.isDisciplineFinished(p.currentDisciplineIndex) {
+		rv := p.dueReview(p.currentDisciplineIndex)
+		if p.isDisciplineFinished(p.currentDisciplineIndex) && rv == nil {
 			p.logger.Debug("discipline '%s' is already finished, adding gap only if current duration is higher than zero", discipline.Name)
 			p.nextDiscipline(hgi)
 			previousDiscipline = discipline
@@ -56,15 +79,44 @@ func (p *Maker) mountInterval(hgi *HourGradeInterval) error {
 			continue
 		}
 
+		if p.isWeeklyLimitReached(p.currentDisciplineIndex) {
+			p.logger.Debug("discipline '%s' already exhausted weekly limit (%s), getting next discipline", discipline.Name, discipline.WeeklyLimit)
+			p.nextDiscipline(hgi)
+			previousDiscipline = discipline
+			continue
+		}
+
+		windowEnd, allowed := p.allowedUntil(discipline, hgi)
+		if !allowed {
+			p.logger.Debug("discipline '%s' is not allowed at %s, getting next discipline", discipline.Name, hgi.Start.Format(LayoutTimeOnly))
+			opening := p.opensAt(discipline, hgi)
+			if !opening.IsZero() && (p.windowOpening.IsZero() || opening.Before(p.windowOpening)) {
+				p.windowOpening = opening
+			}
+
+			p.nextDiscipline(hgi)
+			previousDiscipline = discipline
+			continue
+		}
+
 		p.logger.Debug("discipline '%s' did not exhaust daily limit, checking next content", discipline.Name)
-		content, err := discipline.Next()
+		var (
+			content *Content
+			err     error
+		)
+		if rv != nil {
+			p.logger.Debug("discipline '%s' has a review due since %s, using it as next content", discipline.Name, rv.due.Format(LayoutDateOnly))
+			content = rv.content
+		} else {
+			content, err = discipline.Next()
+		}
+
 		if err == stream.ErrEOF {
 			p.logger.Debug("discipline '%s' reached the end of content list, checking if all disciplines finished", discipline.Name)
 			p.finishedDisciplinesIndexes = append(p.finishedDisciplinesIndexes, p.currentDisciplineIndex)
 
-			if p.isAllDisciplinesFinished() {
+			if p.isFinished() {
 				p.logger.Debug("all disciplines finished, ending planner mount! last discipline = %s", discipline.Name)
-				p.outputWriter.Flush()
 				return stream.ErrEOF
 			}
 
@@ -78,6 +130,30 @@ func (p *Maker) mountInterval(hgi *HourGradeInterval) error {
 			return err
 		}
 
+		if entry := p.progress.Get(content.ID); rv == nil && entry != nil {
+			p.skipLogged(content, entry)
+			continue
+		}
+
+		if rv == nil && !p.prerequisitesPlaced(content) {
+			p.logger.Debug("content '%s' is waiting for its prerequisites, getting next discipline", content.Title)
+			err = discipline.Back()
+			if err != nil {
+				p.logger.Error(err, "could not step back on the discipline's content")
+				return err
+			}
+
+			p.blockedDisciplines[p.currentDisciplineIndex] = true
+			if p.isDeadlocked() {
+				p.logger.Error(ErrUnresolvablePrerequisites, "discipline '%s' can't place '%s'", discipline.Name, content.Title)
+				return ErrUnresolvablePrerequisites
+			}
+
+			p.nextDiscipline(hgi)
+			previousDiscipline = discipline
+			continue
+		}
+
 		p.logger.Debug("discipline's content retrieved. checking if we should include a gap before the content")
 		totalDuration := content.Duration
 		var preGap time.Duration = 0
@@ -96,9 +172,58 @@ func (p *Maker) mountInterval(hgi *HourGradeInterval) error {
 			p.logger.Debug("it's a new content from other disciplines, no gap is required")
 		}
 
+		breakGap := p.breakPolicy.breakBefore(focused, totalDuration, breaks)
+		focusLeft := p.breakPolicy.focusLeft(focused)
+		if breakGap > 0 {
+			p.logger.Debug("%s of continuous study, taking a break of %s before the next content", focused, breakGap)
+			// the break replaces the gap
+			totalDuration -= preGap
+			preGap = 0
+			focusLeft = p.breakPolicy.focusLeft(0)
+		}
+
+		available := minDuration(windowEnd.Sub(hgi.Start)-breakGap, discipline.DailyLimit-p.currentDayDisciplineDuration)
+		available = p.weeklyAvailable(p.currentDisciplineIndex, available) - preGap
+		if focusLeft >= 0 {
+			available = minDuration(available, focusLeft-preGap)
+		}
+
+		if rv == nil && totalDuration > available+preGap && p.canSplit(discipline, content, available) {
+			p.logger.Debug("content doesn't fit but discipline '%s' is splittable, using the %s available", discipline.Name, available)
+			content = p.splitContent(discipline, content, available)
+			totalDuration = content.Duration + preGap
+		}
+
 		p.logger.Debug("checking if discipline current duration (%s) + totalDuration (%s) exhaust discipline daily limit (%s)", p.currentDayDisciplineDuration, totalDuration, discipline.DailyLimit)
-		if (p.currentDayDisciplineDuration + totalDuration) > discipline.DailyLimit {
-			p.logger.Debug("discipline '%s's gap exhausts daily limit, getting next discipline, adding gap (if duration is higher than zero)", discipline.Name)
+		if p.isUnplayable(discipline, content) {
+			p.logger.Debug("content '%s' lasts more than discipline '%s' daily limit or the longest interval, it will never fit", content.Title, discipline.Name)
+			err = p.dropUnplayable(discipline, content, rv)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if (p.currentDayDisciplineDuration+totalDuration) > discipline.DailyLimit || p.exceedsWeeklyLimit(p.currentDisciplineIndex, totalDuration) {
+			p.logger.Debug("discipline '%s's gap exhausts daily or weekly limit, getting next discipline, adding gap (if duration is higher than zero)", discipline.Name)
+			if rv == nil {
+				err = discipline.Back()
+				if err != nil {
+					p.logger.Error(err, "could not step back on the discipline's content")
+					return err
+				}
+			}
+
+			packed, err := p.pack(hgi, focused, breaks)
+			if err != nil {
+				return err
+			}
+
+			if packed {
+				continue
+			}
+
 			p.nextDiscipline(hgi)
 			previousDiscipline = discipline
 			continue
@@ -107,23 +232,39 @@ func (p *Maker) mountInterval(hgi *HourGradeInterval) error {
 		p.logger.Debug("discipline '%s's gap doesn't exhaust daily limit", discipline.Name)
 
 		p.logger.Debug("checking if the content + gap (%s) can be added to time interval (%s-%s)", totalDuration, startStr, endStr)
-		if hgi.End.Sub(hgi.Start) < totalDuration {
+		if windowEnd.Sub(hgi.Start) < totalDuration+breakGap {
 			content.Attempts++
 			p.logger.Debug(
 				"total duration is higher than the time left, checking if this content attempts is higher than %d attempts",
 				MaxContentAttemptsAllowed,
 			)
 			if content.Attempts > MaxContentAttemptsAllowed {
-				return ErrContentDurationUnplayable
+				err = p.dropUnplayable(discipline, content, rv)
+				if err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			p.logger.Debug("content attempts is only %d, so we can attempt again next time", content.Attempts)
-			err = discipline.Back()
+			if rv == nil {
+				err = discipline.Back()
+				if err != nil {
+					p.logger.Error(err, "could not step back on the discipline's content")
+					return err
+				}
+			}
+
+			packed, err := p.pack(hgi, focused, breaks)
 			if err != nil {
-				p.logger.Error(err, "could not step back on the discipline's content")
 				return err
 			}
 
+			if packed {
+				continue
+			}
+
 			p.logger.Debug("as discipline '%s' can't fill with the current content, we'll call the next discipline", discipline.Name)
 			p.nextDiscipline(hgi)
 			continue
@@ -132,12 +273,14 @@ func (p *Maker) mountInterval(hgi *HourGradeInterval) error {
 		p.logger.Debug("content can be added to time interval, adding to PlannerOutput list")
 
 		output := Output{
-			Time:       hgi.Start.Add(preGap),
+			Time:       hgi.Start.Add(breakGap + preGap),
 			Discipline: discipline,
 			Content:    content,
+			Interval:   interval,
 		}
 
-		err = p.outputWriter.Write(output.ToRecord())
+		lastPart := content.split != nil && p.placedPart(content)
+		err = p.writeOutput(output)
 		if err != nil {
 			return err
 		}
@@ -145,13 +288,49 @@ func (p *Maker) mountInterval(hgi *HourGradeInterval) error {
 		previousSubject = content.Subject
 		previousDiscipline = discipline
 		p.currentDayDisciplineDuration += totalDuration
-		hgi.Start = hgi.Start.Add(totalDuration)
+		p.weekDuration[p.currentDisciplineIndex] += totalDuration
+		hgi.Start = hgi.Start.Add(breakGap + totalDuration)
+		if breakGap > 0 {
+			focused = 0
+			breaks++
+		}
+		focused += totalDuration
 		isFirst = false
+		p.lastScheduledAt[p.currentDisciplineIndex] = output.Time
+		p.report.placed(p.currentDisciplineIndex, output, rv != nil)
+		if rv != nil {
+			p.removeReview(rv)
+		} else {
+			p.remainingWork[p.currentDisciplineIndex] -= content.Duration
+			if isAfterDeadline(discipline, output.Time.Add(content.Duration)) {
+				p.logger.Warn("content '%s' of discipline '%s' ends after the discipline's deadline", content.Title, discipline.Name)
+				p.lateWork[p.currentDisciplineIndex] += content.Duration
+			}
+
+			if content.split == nil {
+				p.markPlaced(content)
+				p.scheduleReviews(p.currentDisciplineIndex, content, p.currentDate)
+			} else if lastPart {
+				p.logger.Debug("last part of '%s' placed", content.split.whole.Title)
+				p.markPlaced(content.split.whole)
+				p.scheduleReviews(p.currentDisciplineIndex, content.split.whole, p.currentDate)
+			}
+
+			err = p.finishIfExhausted(p.currentDisciplineIndex)
+			if err != nil {
+				p.logger.Error(err, "could not check the next content of discipline '%s'", discipline.Name)
+				return err
+			}
+		}
+
+		if p.isFinished() {
+			p.logger.Debug("all disciplines and reviews finished, ending planner mount! last discipline = %s", discipline.Name)
+			return stream.ErrEOF
+		}
+
 		p.logger.Debug("inner loop %d finished, starting next", loopCounter)
 	}
 
-	p.outputWriter.Flush()
-
 	p.logger.Debug("procedure for interval  %s-%s finished, calling next interval", initialStr, endStr)
 	return nil
 }
@@ -0,0 +1,40 @@
+package planner
+
+type MakerOption func(p *Maker)
+
+func WithSelectionStrategy(strategy SelectionStrategy) MakerOption {
+	return func(p *Maker) {
+		p.strategy = strategy
+	}
+}
+
+// WithSelectionStrategyFactory gives every maker built with the option a new
+// strategy, so the makers sharing the options, like the estimate's
+// simulations, don't share the strategy's state too.
+func WithSelectionStrategyFactory(factory SelectionStrategyFactory) MakerOption {
+	return func(p *Maker) {
+		p.strategy = factory()
+	}
+}
+
+func WithBreakPolicy(policy *BreakPolicy) MakerOption {
+	return func(p *Maker) {
+		p.breakPolicy = policy
+	}
+}
+
+// WithProgress leaves the contents and reviews logged as done or skipped out of the plan.
+func WithProgress(progress *Progress) MakerOption {
+	return func(p *Maker) {
+		p.progress = progress
+	}
+}
+
+// WithPacking fills the end of the intervals with the content that fits best
+// instead of just moving to the next discipline. Reorderable disciplines look
+// ahead up to window contents, the others only offer their next one.
+func WithPacking(window int) MakerOption {
+	return func(p *Maker) {
+		p.packingWindow = window
+	}
+}
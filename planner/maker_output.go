@@ -0,0 +1,24 @@
+package planner
+
+// writeOutput holds the outputs while there are split contents in progress,
+// as their titles are only known after placing the last part.
+func (p *Maker) writeOutput(output Output) error {
+	p.outputBuffer = append(p.outputBuffer, output)
+	if p.openSplits > 0 {
+		return nil
+	}
+
+	return p.flushOutputs()
+}
+
+func (p *Maker) flushOutputs() error {
+	for _, output := range p.outputBuffer {
+		err := p.sink.Write(output)
+		if err != nil {
+			return err
+		}
+	}
+
+	p.outputBuffer = p.outputBuffer[:0]
+	return nil
+}
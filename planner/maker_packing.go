@@ -0,0 +1,116 @@
+package planner
+
+import "time"
+
+type packingCandidate struct {
+	disciplineIndex int
+	content         *Content
+	isReview        bool
+}
+
+// pack is a no-op unless packing is enabled.
+func (p *Maker) pack(hgi *HourGradeInterval, focused time.Duration, breaks int) (bool, error) {
+	if p.packingWindow <= 0 {
+		return false, nil
+	}
+
+	packed, err := p.packInterval(hgi, focused, breaks)
+	if err != nil {
+		p.logger.Error(err, "could not look for a content to fill the interval")
+	}
+
+	return packed, err
+}
+
+// packInterval looks for the content that best fills the time left on the
+// interval among the next contents of every discipline, even the ones already
+// checked, making its discipline the current one. It returns false if nothing fits.
+func (p *Maker) packInterval(hgi *HourGradeInterval, focused time.Duration, breaks int) (bool, error) {
+	var best *packingCandidate
+	for index, discipline := range p.disciplines {
+		isCurrent := index == p.currentDisciplineIndex
+		windowEnd, allowed := p.allowedUntil(discipline, hgi)
+		if !allowed {
+			continue
+		}
+
+		left := windowEnd.Sub(hgi.Start)
+		limit := discipline.DailyLimit
+		if isCurrent {
+			limit -= p.currentDayDisciplineDuration
+		} else if p.currentDayDisciplineDuration > 0 {
+			// leaving the current discipline adds its subject gap
+			left -= p.disciplines[p.currentDisciplineIndex].SubjectGap
+		}
+
+		limit = p.weeklyAvailable(index, limit)
+		candidates, isReview, err := p.packingCandidates(index)
+		if err != nil {
+			return false, err
+		}
+
+		for _, content := range candidates {
+			if !isReview && (p.progress.Get(content.ID) != nil || !p.prerequisitesPlaced(content)) {
+				continue
+			}
+
+			// the gap depends on the previous content, so the longest one is reserved
+			study := maxDuration(discipline.ContentGap, discipline.SubjectGap) + content.Duration
+			need := study
+			if breakGap := p.breakPolicy.breakBefore(focused, study, breaks); breakGap > 0 {
+				need = breakGap + content.Duration
+			}
+
+			if need > left || study > limit {
+				continue
+			}
+
+			if best == nil || content.Duration > best.content.Duration {
+				best = &packingCandidate{disciplineIndex: index, content: content, isReview: isReview}
+			}
+		}
+	}
+
+	if best == nil {
+		return false, nil
+	}
+
+	discipline := p.disciplines[best.disciplineIndex]
+	p.logger.Debug("packing '%s' of discipline '%s' to fill the interval", best.content.Title, discipline.Name)
+	if !best.isReview {
+		discipline.Promote(best.content)
+	}
+
+	if best.disciplineIndex != p.currentDisciplineIndex {
+		p.leaveDiscipline(hgi)
+		p.currentDisciplineIndex = best.disciplineIndex
+		// it may have been checked before, when its next content didn't fit
+		if p.checkedDisciplines[best.disciplineIndex] {
+			p.checkedDisciplines[best.disciplineIndex] = false
+			p.checkedDisciplinesCount--
+		}
+	}
+
+	return true, nil
+}
+
+// packingCandidates returns the due review of the discipline, if any, or its
+// next contents, as many as its order allows.
+func (p *Maker) packingCandidates(disciplineIndex int) ([]*Content, bool, error) {
+	if rv := p.dueReview(disciplineIndex); rv != nil {
+		return []*Content{rv.content}, true, nil
+	}
+
+	if p.isDisciplineFinished(disciplineIndex) {
+		return nil, false, nil
+	}
+
+	discipline := p.disciplines[disciplineIndex]
+	window := 1
+	if discipline.Reorderable {
+		window = p.packingWindow
+	}
+
+	contents, err := discipline.Lookahead(window)
+	return contents, false, err
+}
@@ -0,0 +1,92 @@
+package planner_test
+
+import (
+	"testing"
+
+	"github.com/kaiquegarcia/gostudy/v2/planner"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Maker_Packing(t *testing.T) {
+	disciplineHeader := []string{"Name", "Filename", "Daily Limit", "Content Gap", "Subject Gap", "Reorderable"}
+	contents := map[string][][]string{
+		"math.csv": {
+			{"Logic", "Sets", "00:30:00"},
+			{"Logic", "Relations", "00:40:00"},
+			{"Logic", "Functions", "00:20:00"},
+			{"Logic", "Induction", "00:30:00"},
+		},
+	}
+
+	t.Run("should fill the interval with the content that fits best", func(t *testing.T) {
+		// Arrange
+		hg := testHourGrade(t, "14:00-15:00")
+		disciplines := testDisciplines(t, [][]string{
+			disciplineHeader,
+			{"Math", "math.csv", "01:00:00", "00:00:00", "00:00:00", "yes"},
+		}, contents)
+
+		// Act
+		outputs, err := mountOutputs(t, hg, disciplines, planner.WithPacking(3))
+
+		// Assert
+		assert.Nil(t, err, "err from Mount should be nil")
+		assert.Equal(t, []string{
+			"2024-02-21 14:00 Math Sets",
+			"2024-02-21 14:30 Math Induction",
+			"2024-02-22 14:00 Math Relations",
+			"2024-02-22 14:40 Math Functions",
+		}, outputs)
+	})
+
+	t.Run("should keep the order of the disciplines that aren't reorderable", func(t *testing.T) {
+		// Arrange
+		hg := testHourGrade(t, "14:00-15:00")
+		disciplines := testDisciplines(t, [][]string{
+			disciplineHeader,
+			{"Math", "math.csv", "01:00:00", "00:00:00", "00:00:00", "no"},
+		}, contents)
+
+		// Act
+		outputs, err := mountOutputs(t, hg, disciplines, planner.WithPacking(3))
+
+		// Assert
+		assert.Nil(t, err, "err from Mount should be nil")
+		assert.Equal(t, []string{
+			"2024-02-21 14:00 Math Sets",
+			"2024-02-22 14:00 Math Relations",
+			"2024-02-22 14:40 Math Functions",
+			"2024-02-23 14:00 Math Induction",
+		}, outputs)
+	})
+
+	t.Run("should fill the interval with the next content of another discipline", func(t *testing.T) {
+		// Arrange
+		hg := testHourGrade(t, "14:00-15:00")
+		disciplines := testDisciplines(t, [][]string{
+			disciplineHeader,
+			{"Math", "math.csv", "01:00:00", "00:00:00", "00:00:00", "no"},
+			{"History", "history.csv", "01:00:00", "00:00:00", "00:00:00", "no"},
+			{"Art", "art.csv", "01:00:00", "00:00:00", "00:00:00", "no"},
+		}, map[string][][]string{
+			"math.csv": {
+				{"Logic", "Sets", "00:40:00"},
+				{"Logic", "Relations", "00:30:00"},
+			},
+			"history.csv": {{"Ancient", "Egypt", "00:10:00"}},
+			"art.csv":     {{"Renaissance", "Painting", "00:20:00"}},
+		})
+
+		// Act
+		outputs, err := mountOutputs(t, hg, disciplines, planner.WithPacking(3))
+
+		// Assert
+		assert.Nil(t, err, "err from Mount should be nil")
+		assert.Equal(t, []string{
+			"2024-02-21 14:00 Math Sets",
+			"2024-02-21 14:40 Art Painting",
+			"2024-02-22 14:00 Math Relations",
+			"2024-02-22 14:30 History Egypt",
+		}, outputs, "art fills the 20 minutes left better than history")
+	})
+}
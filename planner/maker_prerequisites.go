@@ -0,0 +1,37 @@
+package planner
+
+func (p *Maker) prerequisitesPlaced(content *Content) bool {
+	for _, id := range content.Prerequisites {
+		if !p.placedContents[id] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (p *Maker) markPlaced(content *Content) {
+	p.placedContents[content.ID] = true
+	// someone may be waiting for this content, so everyone must be checked again,
+	// even the ones that already had their turn on this interval
+	for index := range p.blockedDisciplines {
+		if p.blockedDisciplines[index] && p.checkedDisciplines[index] {
+			p.checkedDisciplines[index] = false
+			p.checkedDisciplinesCount--
+		}
+
+		p.blockedDisciplines[index] = false
+	}
+}
+
+// isDeadlocked reports if every unfinished discipline is waiting for prerequisites,
+// as none of them will ever be able to place the contents the others are waiting for.
+func (p *Maker) isDeadlocked() bool {
+	for index := range p.disciplines {
+		if !p.isDisciplineFinished(index) && !p.blockedDisciplines[index] {
+			return false
+		}
+	}
+
+	return true
+}
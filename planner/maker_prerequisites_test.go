@@ -0,0 +1,75 @@
+package planner_test
+
+import (
+	"testing"
+
+	"github.com/kaiquegarcia/gostudy/v2/planner"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Maker_Prerequisites(t *testing.T) {
+	disciplineRows := [][]string{
+		{"Name", "Filename", "Daily Limit", "Content Gap", "Subject Gap"},
+		{"Math", "math.csv", "01:00:00", "00:00:00", "00:00:00"},
+		{"Physics", "physics.csv", "01:00:00", "00:00:00", "00:00:00"},
+	}
+
+	t.Run("should place the prerequisites of other disciplines first", func(t *testing.T) {
+		// Arrange
+		hg := testHourGrade(t, "14:00-15:00")
+		disciplines := testDisciplines(t, disciplineRows, map[string][][]string{
+			"math.csv": {
+				{"Calculus", "Integrals", "00:30:00", "math-1", "physics-1"},
+				{"Calculus", "Series", "00:30:00", "math-2", ""},
+			},
+			"physics.csv": {
+				{"Mechanics", "Kinematics", "00:30:00", "physics-1", ""},
+				{"Mechanics", "Dynamics", "00:30:00", "physics-2", "math-1"},
+			},
+		})
+
+		// Act
+		outputs, err := mountOutputs(t, hg, disciplines)
+
+		// Assert
+		assert.Nil(t, err, "err from Mount should be nil")
+		assert.Equal(t, []string{
+			"2024-02-21 14:00 Physics Kinematics",
+			"2024-02-21 14:30 Math Integrals",
+			"2024-02-22 14:00 Math Series",
+			"2024-02-22 14:30 Physics Dynamics",
+		}, outputs)
+	})
+
+	t.Run("should fail when the disciplines are waiting for each other", func(t *testing.T) {
+		// Arrange
+		hg := testHourGrade(t, "14:00-15:00")
+		disciplines := testDisciplines(t, disciplineRows, map[string][][]string{
+			"math.csv":    {{"Calculus", "Integrals", "00:30:00", "math-1", "physics-1"}},
+			"physics.csv": {{"Mechanics", "Kinematics", "00:30:00", "physics-1", "math-1"}},
+		})
+
+		// Act
+		outputs, err := mountOutputs(t, hg, disciplines)
+
+		// Assert
+		assert.ErrorIs(t, err, planner.ErrUnresolvablePrerequisites)
+		assert.Empty(t, outputs, "nothing should be placed")
+	})
+
+	t.Run("should fail when the prerequisite doesn't exist", func(t *testing.T) {
+		// Arrange
+		hg := testHourGrade(t, "14:00-15:00")
+		disciplines := testDisciplines(t, disciplineRows, map[string][][]string{
+			"math.csv":    {{"Calculus", "Integrals", "00:30:00", "math-1", "math-0"}},
+			"physics.csv": {{"Mechanics", "Kinematics", "00:30:00", "physics-1", ""}},
+		})
+
+		// Act
+		outputs, err := mountOutputs(t, hg, disciplines)
+
+		// Assert
+		assert.ErrorIs(t, err, planner.ErrUnresolvablePrerequisites)
+		assert.Equal(t, []string{"2024-02-21 14:00 Physics Kinematics"}, outputs, "the other disciplines should be placed until then")
+	})
+}
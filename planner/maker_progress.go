@@ -0,0 +1,43 @@
+package planner
+
+import "strings"
+
+// loadProgress counts the logged contents as placed, so the contents depending
+// on them don't wait, and warns about the IDs that don't match any content.
+func (p *Maker) loadProgress(knownIDs map[string]bool) {
+	for _, entry := range p.progress.Entries() {
+		p.placedContents[entry.ID] = true
+		baseID, _, _ := strings.Cut(entry.ID, reviewIDSeparator)
+		if !knownIDs[baseID] {
+			p.logger.Warn("the progress of '%s' doesn't match any content, it will be ignored", entry.ID)
+		}
+	}
+}
+
+// skipLogged leaves a content already done or skipped out of the plan,
+// keeping the reviews of the done ones that weren't logged yet.
+func (p *Maker) skipLogged(content *Content, entry *ProgressEntry) {
+	p.logger.Debug("content '%s' is already %s, leaving it out", content.Title, entry.Status)
+	p.report.Disciplines[p.currentDisciplineIndex].Completed = append(
+		p.report.Disciplines[p.currentDisciplineIndex].Completed,
+		content,
+	)
+	p.markPlaced(content)
+	if entry.Status != ProgressDone {
+		return
+	}
+
+	studied := content
+	if entry.ActualDuration > 0 {
+		copied := *content
+		copied.Duration = entry.ActualDuration
+		studied = &copied
+	}
+
+	completedAt := entry.CompletedAt
+	if completedAt.IsZero() {
+		completedAt = p.currentDate
+	}
+
+	p.scheduleReviews(p.currentDisciplineIndex, studied, completedAt)
+}
@@ -0,0 +1,103 @@
+package planner_test
+
+import (
+	"testing"
+
+	"github.com/kaiquegarcia/gostudy/v2/planner"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Maker_Progress(t *testing.T) {
+	disciplineRows := [][]string{
+		{"Name", "Filename", "Daily Limit", "Content Gap", "Subject Gap", "Review Intervals", "Review Duration"},
+		{"Math", "math.csv", "02:00:00", "00:00:00", "00:00:00", "", ""},
+	}
+	contents := map[string][][]string{
+		"math.csv": {
+			{"Logic", "Sets", "01:00:00", "math-1", ""},
+			{"Logic", "Relations", "01:00:00", "math-2", ""},
+			{"Logic", "Functions", "01:00:00", "math-3", "math-2"},
+		},
+	}
+
+	t.Run("should leave the contents done or skipped out of the new plan", func(t *testing.T) {
+		// Arrange
+		hg := testHourGrade(t, "14:00-15:00")
+		disciplines := testDisciplines(t, disciplineRows, contents)
+		progress, err := planner.NewProgressFromRows([][]string{
+			{"ID", "Completed At", "Actual Duration", "Status"},
+			{"math-1", "2024-02-19", "", "done"},
+			{"math-2", "", "", "skipped"},
+		})
+		if !assert.Nil(t, err, "err from NewProgressFromRows should be nil") {
+			t.FailNow()
+		}
+		sink := planner.NewMemoryOutputSink()
+		maker := planner.NewMakerWithSink(testLogger(), hg, disciplines, testStartDate, sink, planner.WithProgress(progress))
+		defer maker.Close()
+
+		// Act
+		err = mountWithin(t, maker)
+
+		// Assert
+		assert.Nil(t, err, "err from Mount should be nil")
+		assert.Equal(t, []string{"2024-02-21 14:00 Math Functions"}, formatOutputs(sink.Outputs), "the skipped prerequisite should count as placed")
+		assert.Len(t, maker.Report().Disciplines[0].Completed, 2, "the report should count the contents left out")
+	})
+
+	t.Run("should carry the missed reviews of the contents done forward", func(t *testing.T) {
+		// Arrange
+		hg := testHourGrade(t, "14:00-16:00")
+		disciplines := testDisciplines(t, [][]string{
+			disciplineRows[0],
+			{"Math", "math.csv", "02:00:00", "00:00:00", "00:00:00", "1;10", "50%"},
+		}, contents)
+		progress, err := planner.NewProgressFromRows([][]string{
+			{"ID", "Completed At", "Actual Duration", "Status"},
+			{"math-1", "2024-02-12", "00:40:00", "done"},
+		})
+		if !assert.Nil(t, err, "err from NewProgressFromRows should be nil") {
+			t.FailNow()
+		}
+
+		// Act
+		outputs, err := mountOutputs(t, hg, disciplines, planner.WithProgress(progress))
+
+		// Assert
+		assert.Nil(t, err, "err from Mount should be nil")
+		assert.Equal(t, []string{
+			"2024-02-21 14:00 Math Sets (review 1/2)",
+			"2024-02-21 14:20 Math Relations",
+			"2024-02-22 14:00 Math Sets (review 2/2)",
+			"2024-02-22 14:20 Math Relations (review 1/2)",
+			"2024-02-22 14:50 Math Functions",
+			"2024-02-23 14:00 Math Functions (review 1/2)",
+			"2024-03-02 14:00 Math Relations (review 2/2)",
+			"2024-03-03 14:00 Math Functions (review 2/2)",
+		}, outputs, "the overdue review should be the first, half as long as the actual study")
+	})
+
+	t.Run("should leave the reviews already done out of the new plan", func(t *testing.T) {
+		// Arrange
+		hg := testHourGrade(t, "14:00-16:00")
+		disciplines := testDisciplines(t, [][]string{
+			disciplineRows[0],
+			{"Math", "math.csv", "02:00:00", "00:00:00", "00:00:00", "1;10", "50%"},
+		}, map[string][][]string{"math.csv": {{"Logic", "Sets", "01:00:00", "math-1", ""}}})
+		progress, err := planner.NewProgressFromRows([][]string{
+			{"ID", "Completed At", "Actual Duration", "Status"},
+			{"math-1", "2024-02-12", "", "done"},
+			{"math-1@review-1", "2024-02-13", "", "done"},
+		})
+		if !assert.Nil(t, err, "err from NewProgressFromRows should be nil") {
+			t.FailNow()
+		}
+
+		// Act
+		outputs, err := mountOutputs(t, hg, disciplines, planner.WithProgress(progress))
+
+		// Assert
+		assert.Nil(t, err, "err from Mount should be nil")
+		assert.Equal(t, []string{"2024-02-22 14:00 Math Sets (review 2/2)"}, outputs)
+	})
+}
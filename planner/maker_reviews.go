@@ -0,0 +1,61 @@
+package planner
+
+import "time"
+
+// scheduleReviews counts the review days from the given date, leaving out the
+// reviews already logged on the progress.
+func (p *Maker) scheduleReviews(disciplineIndex int, content *Content, from time.Time) {
+	discipline := p.disciplines[disciplineIndex]
+	total := len(discipline.ReviewIntervals)
+	// a review must fit on a single interval, even if the content itself was split
+	duration := minDuration(discipline.ReviewDuration.For(content), discipline.DailyLimit)
+	duration = minDuration(duration, p.hg.LongestInterval())
+	for index, days := range discipline.ReviewIntervals {
+		rv := &review{
+			disciplineIndex: disciplineIndex,
+			content:         newReviewContent(content, duration, index+1, total),
+			due:             from.AddDate(0, 0, days),
+		}
+		if p.progress.Get(rv.content.ID) != nil {
+			continue
+		}
+
+		p.logger.Debug(
+			"scheduling review %d/%d of '%s' to %s",
+			index+1, total, content.Title, rv.due.Format(LayoutDateOnly),
+		)
+		p.reviews = append(p.reviews, rv)
+	}
+}
+
+func (p *Maker) dueReview(disciplineIndex int) *review {
+	var found *review
+	for _, rv := range p.reviews {
+		if rv.disciplineIndex != disciplineIndex || rv.due.After(p.currentDate) {
+			continue
+		}
+
+		if found == nil || rv.due.Before(found.due) {
+			found = rv
+		}
+	}
+
+	return found
+}
+
+func (p *Maker) removeReview(target *review) {
+	for index, rv := range p.reviews {
+		if rv == target {
+			p.reviews = append(p.reviews[:index], p.reviews[index+1:]...)
+			return
+		}
+	}
+}
+
+func (p *Maker) hasPendingReviews() bool {
+	return len(p.reviews) > 0
+}
+
+func (p *Maker) isFinished() bool {
+	return p.isAllDisciplinesFinished() && !p.hasPendingReviews()
+}
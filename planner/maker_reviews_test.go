@@ -0,0 +1,65 @@
+package planner_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Maker_Reviews(t *testing.T) {
+	disciplineHeader := []string{"Name", "Filename", "Daily Limit", "Content Gap", "Subject Gap", "Review Intervals", "Review Duration"}
+
+	t.Run("should space the reviews by the intervals after each content", func(t *testing.T) {
+		// Arrange
+		hg := testHourGrade(t, "14:00-16:00")
+		disciplines := testDisciplines(t, [][]string{
+			disciplineHeader,
+			{"Math", "math.csv", "02:00:00", "00:00:00", "00:00:00", "1;3", "50%"},
+		}, map[string][][]string{
+			"math.csv": {
+				{"Logic", "Sets", "01:00:00"},
+				{"Logic", "Relations", "01:00:00"},
+			},
+		})
+
+		// Act
+		outputs, err := mountOutputs(t, hg, disciplines)
+
+		// Assert
+		assert.Nil(t, err, "err from Mount should be nil")
+		assert.Equal(t, []string{
+			"2024-02-21 14:00 Math Sets",
+			"2024-02-21 15:00 Math Relations",
+			"2024-02-22 14:00 Math Sets (review 1/2)",
+			"2024-02-22 14:30 Math Relations (review 1/2)",
+			"2024-02-24 14:00 Math Sets (review 2/2)",
+			"2024-02-24 14:30 Math Relations (review 2/2)",
+		}, outputs)
+	})
+
+	t.Run("should place the due reviews before the next contents", func(t *testing.T) {
+		// Arrange
+		hg := testHourGrade(t, "14:00-16:00")
+		disciplines := testDisciplines(t, [][]string{
+			disciplineHeader,
+			{"Math", "math.csv", "01:30:00", "00:00:00", "00:00:00", "1", "00:30:00"},
+		}, map[string][][]string{
+			"math.csv": {
+				{"Logic", "Sets", "01:00:00"},
+				{"Logic", "Relations", "01:00:00"},
+			},
+		})
+
+		// Act
+		outputs, err := mountOutputs(t, hg, disciplines)
+
+		// Assert
+		assert.Nil(t, err, "err from Mount should be nil")
+		assert.Equal(t, []string{
+			"2024-02-21 14:00 Math Sets",
+			"2024-02-22 14:00 Math Sets (review 1/1)",
+			"2024-02-22 14:30 Math Relations",
+			"2024-02-23 14:00 Math Relations (review 1/1)",
+		}, outputs)
+	})
+}
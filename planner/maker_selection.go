@@ -0,0 +1,83 @@
+package planner
+
+import "time"
+
+func (p *Maker) resetCheckedDisciplines() {
+	p.checkedDisciplinesCount = 0
+	for index := range p.checkedDisciplines {
+		p.checkedDisciplines[index] = false
+	}
+}
+
+func (p *Maker) selectDiscipline() {
+	candidates := make([]SelectionCandidate, 0, len(p.disciplines))
+	for index, discipline := range p.disciplines {
+		if p.checkedDisciplines[index] {
+			continue
+		}
+
+		candidates = append(candidates, SelectionCandidate{
+			Index:           index,
+			Discipline:      discipline,
+			LastScheduledAt: p.lastScheduledAt[index],
+			RemainingWork:   p.remainingWork[index],
+			Slack:           p.deadlineCapacity[index] - p.remainingWork[index],
+		})
+	}
+
+	candidates = p.weeklyMinimumCandidates(candidates)
+	if len(candidates) == 0 {
+		// every discipline had its turn, just move forward so the next interval
+		// doesn't start with the same discipline that ended this one
+		p.currentDisciplineIndex++
+		p.currentDisciplineIndex %= len(p.disciplines)
+		return
+	}
+
+	p.currentDisciplineIndex = p.strategy.Select(p.currentDisciplineIndex, candidates)
+	p.logger.Debug("selected discipline '%s' for the next turn", p.disciplines[p.currentDisciplineIndex].Name)
+}
+
+func (p *Maker) loadRemainingWork() error {
+	knownIDs := map[string]bool{}
+	for index, discipline := range p.disciplines {
+		contents, err := discipline.Contents()
+		if err != nil {
+			p.logger.Error(err, "could not calculate the workload of discipline '%s'", discipline.Name)
+			return err
+		}
+
+		var workload time.Duration = 0
+		for _, content := range contents {
+			knownIDs[content.ID] = true
+			if p.progress.Get(content.ID) == nil {
+				workload += content.Duration
+			}
+		}
+
+		p.remainingWork[index] = workload
+	}
+
+	if p.progress != nil {
+		p.loadProgress(knownIDs)
+	}
+
+	return nil
+}
+
+// weeklyMinimumCandidates narrows the candidates to the disciplines that didn't
+// reach their weekly minimum yet, if there are any.
+func (p *Maker) weeklyMinimumCandidates(candidates []SelectionCandidate) []SelectionCandidate {
+	below := make([]SelectionCandidate, 0, len(candidates))
+	for _, candidate := range candidates {
+		if p.isBelowWeeklyMinimum(candidate.Index) && !p.isWeeklyLimitReached(candidate.Index) {
+			below = append(below, candidate)
+		}
+	}
+
+	if len(below) == 0 {
+		return candidates
+	}
+
+	return below
+}
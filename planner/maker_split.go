@@ -0,0 +1,58 @@
+package planner
+
+import "time"
+
+func (p *Maker) canSplit(discipline *Discipline, content *Content, available time.Duration) bool {
+	return discipline.Splittable && available >= MinSplitPartDuration && content.Duration > available
+}
+
+// splitContent returns a part of the content that lasts the available time and
+// gives the rest of it back to the discipline, to be placed on the next slot.
+func (p *Maker) splitContent(discipline *Discipline, content *Content, available time.Duration) *Content {
+	split := content.split
+	if split == nil {
+		split = &contentSplit{whole: content, parts: make([]*Content, 0)}
+		p.openSplits++
+	}
+
+	part := &Content{
+		ID:           content.ID,
+		Subject:      content.Subject,
+		Title:        content.Title,
+		Duration:     available,
+		Reference:    content.Reference,
+		Extra:        content.Extra,
+		Attempts:     0,
+		positionalID: content.positionalID,
+		split:        split,
+		hasRemainder: true,
+	}
+	remainder := &Content{
+		ID:           content.ID,
+		Subject:      content.Subject,
+		Title:        content.Title,
+		Duration:     content.Duration - available,
+		Reference:    content.Reference,
+		Extra:        content.Extra,
+		Attempts:     0,
+		positionalID: content.positionalID,
+		split:        split,
+	}
+
+	p.logger.Debug("splitting '%s' in a part of %s and a remainder of %s", content.Title, part.Duration, remainder.Duration)
+	discipline.Hold(remainder)
+	return part
+}
+
+// placedPart registers the part on its split and reports if it was the last one.
+func (p *Maker) placedPart(part *Content) bool {
+	split := part.split
+	split.parts = append(split.parts, part)
+	if part.hasRemainder {
+		return false
+	}
+
+	split.nameParts()
+	p.openSplits--
+	return true
+}
@@ -0,0 +1,79 @@
+package planner_test
+
+import (
+	"testing"
+
+	"github.com/kaiquegarcia/gostudy/v2/planner"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Maker_Split(t *testing.T) {
+	disciplineHeader := []string{"Name", "Filename", "Daily Limit", "Content Gap", "Subject Gap", "Splittable"}
+
+	t.Run("should split the contents longer than the slots in named parts", func(t *testing.T) {
+		// Arrange
+		hg := testHourGrade(t, "14:00-15:00")
+		disciplines := testDisciplines(t, [][]string{
+			disciplineHeader,
+			{"Math", "math.csv", "01:00:00", "00:00:00", "00:00:00", "yes"},
+		}, map[string][][]string{
+			"math.csv": {
+				{"Logic", "Lecture", "02:30:00"},
+				{"Logic", "Sets", "00:30:00"},
+			},
+		})
+
+		// Act
+		outputs, err := mountOutputs(t, hg, disciplines)
+
+		// Assert
+		assert.Nil(t, err, "err from Mount should be nil")
+		assert.Equal(t, []string{
+			"2024-02-21 14:00 Math Lecture (part 1/3)",
+			"2024-02-22 14:00 Math Lecture (part 2/3)",
+			"2024-02-23 14:00 Math Lecture (part 3/3)",
+			"2024-02-23 14:30 Math Sets",
+		}, outputs)
+	})
+
+	t.Run("should not split in parts shorter than the minimum", func(t *testing.T) {
+		// Arrange
+		hg := testHourGrade(t, "14:00-15:00")
+		disciplines := testDisciplines(t, [][]string{
+			disciplineHeader,
+			{"Math", "math.csv", "01:00:00", "00:00:00", "00:00:00", "yes"},
+		}, map[string][][]string{
+			"math.csv": {
+				{"Logic", "Intro", "00:55:00"},
+				{"Logic", "Lecture", "01:00:00"},
+			},
+		})
+
+		// Act
+		outputs, err := mountOutputs(t, hg, disciplines)
+
+		// Assert
+		assert.Nil(t, err, "err from Mount should be nil")
+		assert.Equal(t, []string{
+			"2024-02-21 14:00 Math Intro",
+			"2024-02-22 14:00 Math Lecture",
+		}, outputs)
+	})
+
+	t.Run("should refuse long contents of disciplines that aren't splittable", func(t *testing.T) {
+		// Arrange
+		hg := testHourGrade(t, "14:00-15:00")
+		disciplines := testDisciplines(t, [][]string{
+			disciplineHeader,
+			{"Math", "math.csv", "01:00:00", "00:00:00", "00:00:00", "no"},
+		}, map[string][][]string{
+			"math.csv": {{"Logic", "Lecture", "02:30:00"}},
+		})
+
+		// Act
+		_, err := mountOutputs(t, hg, disciplines)
+
+		// Assert
+		assert.ErrorIs(t, err, planner.ErrContentDurationUnplayable)
+	})
+}
@@ -0,0 +1,158 @@
+package planner_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kaiquegarcia/gostudy/v2/logging"
+	"github.com/kaiquegarcia/gostudy/v2/planner"
+	"github.com/stretchr/testify/assert"
+)
+
+// 2024-02-21 is a wednesday
+var testStartDate = time.Date(2024, 2, 21, 0, 0, 0, 0, time.UTC)
+
+var testContentHeader = []string{"Subject", "Title", "Duration", "ID", "Prerequisites"}
+
+func testLogger() logging.Logger {
+	printer := logging.NewPrinterByFunction(func(format string, arguments ...interface{}) (int, error) {
+		return 0, nil
+	})
+	return logging.NewLogger(printer, logging.LevelPanic)
+}
+
+// testHourGrade has the same intervals on every day of week.
+func testHourGrade(t *testing.T, intervals ...string) *planner.HourGrade {
+	rows := [][]string{append([]string{"Day of Week"}, intervals...)}
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		rows = append(rows, append([]string{weekday.String()}, intervals...))
+	}
+
+	hg, err := planner.NewHourGradeFromRow(rows)
+	if !assert.Nil(t, err, "err from NewHourGradeFromRow should be nil") {
+		t.FailNow()
+	}
+
+	return hg
+}
+
+// testDisciplines reads the disciplines rows (header included) with their
+// contents rows by filename, prepending testContentHeader to them.
+func testDisciplines(t *testing.T, rows [][]string, contents map[string][][]string) []*planner.Discipline {
+	withHeader := map[string][][]string{}
+	for filename, contentRows := range contents {
+		withHeader[filename] = append([][]string{testContentHeader}, contentRows...)
+	}
+
+	disciplines, err := planner.NewDisciplineFromRowsWithContents(rows, withHeader)
+	if !assert.Nil(t, err, "err from NewDisciplineFromRowsWithContents should be nil") {
+		t.FailNow()
+	}
+
+	return disciplines
+}
+
+// mountWithin fails the test instead of hanging when the maker never ends.
+func mountWithin(t *testing.T, maker *planner.Maker) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- maker.Mount()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(5 * time.Second):
+		t.Fatal("Mount should not hang")
+		return nil
+	}
+}
+
+// mountOutputs mounts the plan from testStartDate, returning the outputs as
+// "yyyy-mm-dd hh:mm Discipline Title".
+func mountOutputs(t *testing.T, hg *planner.HourGrade, disciplines []*planner.Discipline, opts ...planner.MakerOption) ([]string, error) {
+	sink := planner.NewMemoryOutputSink()
+	maker := planner.NewMakerWithSink(testLogger(), hg, disciplines, testStartDate, sink, opts...)
+	err := mountWithin(t, maker)
+	assert.Nil(t, maker.Close(), "err from Close should be nil")
+	return formatOutputs(sink.Outputs), err
+}
+
+func formatOutputs(outputs []planner.Output) []string {
+	formatted := make([]string, len(outputs))
+	for index, output := range outputs {
+		formatted[index] = fmt.Sprintf(
+			"%s %s %s",
+			output.Time.Format("2006-01-02 15:04"),
+			output.Discipline.Name,
+			output.Content.Title,
+		)
+	}
+
+	return formatted
+}
+
+// failingCloseSink keeps the outputs, but fails to write its ending.
+type failingCloseSink struct {
+	*planner.MemoryOutputSink
+}
+
+func (s failingCloseSink) Close() error {
+	return errTestSinkClose
+}
+
+var errTestSinkClose = errors.New("disk full")
+
+func Test_Maker_Close(t *testing.T) {
+	t.Run("should return the error of the sink", func(t *testing.T) {
+		// Arrange
+		hg := testHourGrade(t, "14:00-15:00")
+		disciplines := testDisciplines(t, [][]string{
+			{"Name", "Filename", "Daily Limit", "Content Gap", "Subject Gap"},
+			{"Math", "math.csv", "01:00:00", "00:00:00", "00:00:00"},
+		}, map[string][][]string{
+			"math.csv": {{"Logic", "Sets", "01:00:00"}},
+		})
+		sink := failingCloseSink{planner.NewMemoryOutputSink()}
+		maker := planner.NewMakerWithSink(testLogger(), hg, disciplines, testStartDate, sink)
+
+		// Act
+		mountErr := mountWithin(t, maker)
+		closeErr := maker.Close()
+
+		// Assert
+		assert.Nil(t, mountErr, "err from Mount should be nil")
+		assert.ErrorIs(t, closeErr, errTestSinkClose)
+		assert.Len(t, sink.Outputs, 1, "the outputs should be written before closing")
+	})
+}
+
+func Test_Maker_DailyLimit(t *testing.T) {
+	t.Run("should keep the content whose gap exceeds the daily limit for the next day", func(t *testing.T) {
+		// Arrange
+		hg := testHourGrade(t, "14:00-16:00")
+		disciplines := testDisciplines(t, [][]string{
+			{"Name", "Filename", "Daily Limit", "Content Gap", "Subject Gap"},
+			{"Math", "math.csv", "01:00:00", "00:10:00", "00:00:00"},
+		}, map[string][][]string{
+			"math.csv": {
+				{"Logic", "Sets", "00:30:00"},
+				{"Logic", "Relations", "00:30:00"},
+				{"Logic", "Functions", "00:20:00"},
+			},
+		})
+
+		// Act
+		outputs, err := mountOutputs(t, hg, disciplines)
+
+		// Assert
+		assert.Nil(t, err, "err from Mount should be nil")
+		assert.Equal(t, []string{
+			"2024-02-21 14:00 Math Sets",
+			"2024-02-22 14:00 Math Relations",
+			"2024-02-22 14:40 Math Functions",
+		}, outputs, "relations only fits the daily limit without the gap before it")
+	})
+}
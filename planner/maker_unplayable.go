@@ -0,0 +1,44 @@
+package planner
+
+import "github.com/kaiquegarcia/gostudy/v2/stream"
+
+func (p *Maker) isUnplayable(discipline *Discipline, content *Content) bool {
+	if content.Duration > discipline.DailyLimit && !discipline.Splittable {
+		return true
+	}
+
+	if discipline.WeeklyLimit > 0 && content.Duration > discipline.WeeklyLimit && !discipline.Splittable {
+		return true
+	}
+
+	if len(discipline.TimeWindows) > 0 && content.Duration > discipline.longestWindow() && !discipline.Splittable {
+		return true
+	}
+
+	return content.Duration > p.hg.LongestInterval() && !discipline.Splittable
+}
+
+// dropUnplayable fails the mount, unless it's a simulation: then the content is
+// left out of the plan and reported.
+func (p *Maker) dropUnplayable(discipline *Discipline, content *Content, rv *review) error {
+	if !p.dryRun {
+		return ErrContentDurationUnplayable
+	}
+
+	p.logger.Warn("content '%s' of discipline '%s' is unplayable, leaving it out", content.Title, discipline.Name)
+	dr := p.report.Disciplines[p.currentDisciplineIndex]
+	dr.Unplayable = append(dr.Unplayable, content)
+	if rv != nil {
+		p.removeReview(rv)
+	} else {
+		p.remainingWork[p.currentDisciplineIndex] -= content.Duration
+		// the contents depending on it are still simulated
+		p.markPlaced(content)
+	}
+
+	if p.isFinished() {
+		return stream.ErrEOF
+	}
+
+	return nil
+}
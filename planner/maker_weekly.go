@@ -0,0 +1,89 @@
+package planner
+
+import "time"
+
+type WeeklyShortfall struct {
+	Discipline   *Discipline
+	Week         time.Time
+	MissingHours time.Duration
+}
+
+// weekStart returns the monday of the date's week
+func weekStart(date time.Time) time.Time {
+	offset := (int(date.Weekday()) + 6) % 7
+	return dateOnly(date).AddDate(0, 0, -offset)
+}
+
+// startWeek resets the weekly durations when the date belongs to a new week,
+// checking the minimums of the week that just ended.
+func (p *Maker) startWeek(date time.Time) {
+	week := weekStart(date)
+	if week.Equal(p.currentWeek) {
+		return
+	}
+
+	if !p.currentWeek.IsZero() {
+		p.checkWeeklyMinimums()
+	}
+
+	p.logger.Debug("starting week of %s", week.Format(LayoutDateOnly))
+	p.currentWeek = week
+	for index := range p.weekDuration {
+		p.weekDuration[index] = 0
+	}
+}
+
+func (p *Maker) checkWeeklyMinimums() {
+	// the plan may start in the middle of the first week, it's not fair to check it
+	if p.currentWeek.Before(dateOnly(p.report.StartDate)) {
+		return
+	}
+
+	for index, discipline := range p.disciplines {
+		if !p.isBelowWeeklyMinimum(index) {
+			continue
+		}
+
+		shortfall := WeeklyShortfall{
+			Discipline:   discipline,
+			Week:         p.currentWeek,
+			MissingHours: discipline.MinWeekly - p.weekDuration[index],
+		}
+		p.logger.Warn(
+			"discipline '%s' is missing %s to reach its weekly minimum on the week of %s",
+			discipline.Name,
+			shortfall.MissingHours,
+			shortfall.Week.Format(LayoutDateOnly),
+		)
+		p.report.WeeklyShortfalls = append(p.report.WeeklyShortfalls, shortfall)
+	}
+}
+
+func (p *Maker) isBelowWeeklyMinimum(disciplineIndex int) bool {
+	discipline := p.disciplines[disciplineIndex]
+	if discipline.MinWeekly == 0 || p.remainingWork[disciplineIndex] <= 0 || p.isDisciplineFinished(disciplineIndex) {
+		return false
+	}
+
+	return p.weekDuration[disciplineIndex] < discipline.MinWeekly
+}
+
+func (p *Maker) isWeeklyLimitReached(disciplineIndex int) bool {
+	limit := p.disciplines[disciplineIndex].WeeklyLimit
+	return limit > 0 && p.weekDuration[disciplineIndex] >= limit
+}
+
+func (p *Maker) exceedsWeeklyLimit(disciplineIndex int, duration time.Duration) bool {
+	limit := p.disciplines[disciplineIndex].WeeklyLimit
+	return limit > 0 && p.weekDuration[disciplineIndex]+duration > limit
+}
+
+// weeklyAvailable is how much the discipline can still take this week, limited by the given duration.
+func (p *Maker) weeklyAvailable(disciplineIndex int, duration time.Duration) time.Duration {
+	limit := p.disciplines[disciplineIndex].WeeklyLimit
+	if limit == 0 {
+		return duration
+	}
+
+	return minDuration(duration, limit-p.weekDuration[disciplineIndex])
+}
@@ -0,0 +1,103 @@
+package planner_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kaiquegarcia/gostudy/v2/planner"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Maker_Weekly(t *testing.T) {
+	disciplineHeader := []string{"Name", "Filename", "Daily Limit", "Content Gap", "Subject Gap", "Weekly Limit", "Min Weekly"}
+
+	t.Run("should stop placing the discipline until the next week once it reaches the weekly limit", func(t *testing.T) {
+		// Arrange
+		hg := testHourGrade(t, "14:00-15:00")
+		disciplines := testDisciplines(t, [][]string{
+			disciplineHeader,
+			{"Math", "math.csv", "01:00:00", "00:00:00", "00:00:00", "02:00:00", ""},
+		}, map[string][][]string{
+			"math.csv": {
+				{"Logic", "Sets", "01:00:00"},
+				{"Logic", "Relations", "01:00:00"},
+				{"Logic", "Functions", "01:00:00"},
+				{"Logic", "Induction", "01:00:00"},
+			},
+		})
+
+		// Act
+		outputs, err := mountOutputs(t, hg, disciplines)
+
+		// Assert
+		assert.Nil(t, err, "err from Mount should be nil")
+		assert.Equal(t, []string{
+			"2024-02-21 14:00 Math Sets",
+			"2024-02-22 14:00 Math Relations",
+			"2024-02-26 14:00 Math Functions",
+			"2024-02-27 14:00 Math Induction",
+		}, outputs)
+	})
+
+	t.Run("should prefer the disciplines below their weekly minimum", func(t *testing.T) {
+		// Arrange
+		hg := testHourGrade(t, "14:00-15:00")
+		disciplines := testDisciplines(t, [][]string{
+			disciplineHeader,
+			{"Math", "math.csv", "01:00:00", "00:00:00", "00:00:00", "", ""},
+			{"History", "history.csv", "01:00:00", "00:00:00", "00:00:00", "", "02:00:00"},
+		}, map[string][][]string{
+			"math.csv": {
+				{"Logic", "Sets", "01:00:00"},
+				{"Logic", "Relations", "01:00:00"},
+			},
+			"history.csv": {
+				{"Ancient", "Egypt", "01:00:00"},
+				{"Ancient", "Rome", "01:00:00"},
+			},
+		})
+
+		// Act
+		outputs, err := mountOutputs(t, hg, disciplines)
+
+		// Assert
+		assert.Nil(t, err, "err from Mount should be nil")
+		assert.Equal(t, []string{
+			"2024-02-21 14:00 History Egypt",
+			"2024-02-22 14:00 History Rome",
+			"2024-02-23 14:00 Math Sets",
+			"2024-02-24 14:00 Math Relations",
+		}, outputs)
+	})
+
+	t.Run("should report the weeks that didn't reach the weekly minimum", func(t *testing.T) {
+		// Arrange
+		hg := testHourGrade(t, "14:00-14:30")
+		rows := make([][]string, 0)
+		for i := 1; i <= 13; i++ {
+			rows = append(rows, []string{"Ancient", fmt.Sprintf("Chapter %d", i), "00:30:00"})
+		}
+		disciplines := testDisciplines(t, [][]string{
+			disciplineHeader,
+			{"History", "history.csv", "01:00:00", "00:00:00", "00:00:00", "", "05:00:00"},
+		}, map[string][][]string{"history.csv": rows})
+		sink := planner.NewMemoryOutputSink()
+		maker := planner.NewMakerWithSink(testLogger(), hg, disciplines, testStartDate, sink)
+		defer maker.Close()
+
+		// Act
+		err := mountWithin(t, maker)
+
+		// Assert
+		assert.Nil(t, err, "err from Mount should be nil")
+		assert.Len(t, sink.Outputs, 13, "every content should be placed")
+		shortfalls := maker.Report().WeeklyShortfalls
+		if assert.Len(t, shortfalls, 1, "only the first full week should be checked") {
+			assert.Equal(t, "History", shortfalls[0].Discipline.Name)
+			assert.Equal(t, "2024-02-26", shortfalls[0].Week.Format(planner.LayoutDateOnly))
+			assert.Equal(t, 90*time.Minute, shortfalls[0].MissingHours, "7 slots of 30 minutes are 1h30 short of 5 hours")
+		}
+		assert.True(t, maker.Report().HasProblems(), "the report should have problems")
+	})
+}
@@ -1,11 +1,20 @@
 package planner
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type Output struct {
 	Time       time.Time
 	Discipline *Discipline
 	Content    *Content
+	// Interval is the hour grade interval the content was placed on, as a whole
+	Interval *HourGradeInterval
+}
+
+func OutputHeader() []string {
+	return []string{"Datetime", "Discipline", "Subject", "Title", "Reference", "Duration"}
 }
 
 func (po Output) ToRecord() []string {
@@ -18,3 +27,26 @@ func (po Output) ToRecord() []string {
 		po.Content.Duration.String(),
 	}
 }
+
+// MarshalJSON writes the same fields of ToRecord, plus the content ID and the extra columns of the content.
+func (po Output) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Datetime   time.Time         `json:"datetime"`
+		Discipline string            `json:"discipline"`
+		ID         string            `json:"id"`
+		Subject    string            `json:"subject"`
+		Title      string            `json:"title"`
+		Reference  string            `json:"reference"`
+		Duration   string            `json:"duration"`
+		Extra      map[string]string `json:"extra,omitempty"`
+	}{
+		Datetime:   po.Time,
+		Discipline: po.Discipline.Name,
+		ID:         po.Content.ID,
+		Subject:    po.Content.Subject,
+		Title:      po.Content.Title,
+		Reference:  po.Content.Reference,
+		Duration:   po.Content.Duration.String(),
+		Extra:      po.Content.Extra,
+	})
+}
@@ -0,0 +1,187 @@
+package planner
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+)
+
+const (
+	OutputFormatCSV  = "csv"
+	OutputFormatJSON = "json"
+)
+
+// OutputSink receives the outputs of the plan, in order, as soon as they're final.
+type OutputSink interface {
+	Write(output Output) error
+	Close() error
+}
+
+// NewOutputSink writes the outputs to w on the given format, closing w with the sink.
+func NewOutputSink(format string, w io.WriteCloser) (OutputSink, error) {
+	switch format {
+	case OutputFormatCSV:
+		return NewCSVOutputSink(w)
+	case OutputFormatJSON:
+		return NewJSONOutputSink(w), nil
+	case OutputFormatICS:
+		return NewICSOutputSink(w)
+	case OutputFormatMarkdown:
+		return NewMarkdownOutputSink(w)
+	case OutputFormatHTML:
+		return NewHTMLOutputSink(w)
+	}
+
+	return nil, ErrUnknownOutputFormat
+}
+
+// IsOutputFormat reports if NewOutputSink knows the format.
+func IsOutputFormat(format string) bool {
+	switch format {
+	case OutputFormatCSV, OutputFormatJSON, OutputFormatICS, OutputFormatMarkdown, OutputFormatHTML:
+		return true
+	}
+
+	return false
+}
+
+type csvOutputSink struct {
+	file   io.WriteCloser
+	writer *csv.Writer
+}
+
+func NewCSVOutputSink(w io.WriteCloser) (OutputSink, error) {
+	cw := csv.NewWriter(w)
+	cw.Write(OutputHeader())
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return nil, err
+	}
+
+	return &csvOutputSink{file: w, writer: cw}, nil
+}
+
+func (s *csvOutputSink) Write(output Output) error {
+	err := s.writer.Write(output.ToRecord())
+	if err != nil {
+		return err
+	}
+
+	s.writer.Flush()
+	return s.writer.Error()
+}
+
+func (s *csvOutputSink) Close() error {
+	s.writer.Flush()
+	if err := s.writer.Error(); err != nil {
+		s.file.Close()
+		return err
+	}
+
+	return s.file.Close()
+}
+
+// jsonOutputSink writes a JSON array, one output at a time.
+type jsonOutputSink struct {
+	file    io.WriteCloser
+	written int
+}
+
+func NewJSONOutputSink(w io.WriteCloser) OutputSink {
+	return &jsonOutputSink{file: w}
+}
+
+func (s *jsonOutputSink) Write(output Output) error {
+	data, err := json.Marshal(output)
+	if err != nil {
+		return err
+	}
+
+	prefix := ",\n  "
+	if s.written == 0 {
+		prefix = "[\n  "
+	}
+
+	_, err = s.file.Write(append([]byte(prefix), data...))
+	if err != nil {
+		return err
+	}
+
+	s.written++
+	return nil
+}
+
+func (s *jsonOutputSink) Close() error {
+	end := "\n]\n"
+	if s.written == 0 {
+		end = "[]\n"
+	}
+
+	_, err := s.file.Write([]byte(end))
+	if err != nil {
+		s.file.Close()
+		return err
+	}
+
+	return s.file.Close()
+}
+
+// MemoryOutputSink keeps the outputs on a slice, for the ones using the planner as a library.
+type MemoryOutputSink struct {
+	Outputs []Output
+}
+
+func NewMemoryOutputSink() *MemoryOutputSink {
+	return &MemoryOutputSink{Outputs: make([]Output, 0)}
+}
+
+func (s *MemoryOutputSink) Write(output Output) error {
+	s.Outputs = append(s.Outputs, output)
+	return nil
+}
+
+func (s *MemoryOutputSink) Close() error {
+	return nil
+}
+
+type multiOutputSink struct {
+	sinks []OutputSink
+}
+
+// NewMultiOutputSink fans out every output to all the sinks, in order.
+func NewMultiOutputSink(sinks ...OutputSink) OutputSink {
+	return &multiOutputSink{sinks: sinks}
+}
+
+func (s *multiOutputSink) Write(output Output) error {
+	for _, sink := range s.sinks {
+		err := sink.Write(output)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *multiOutputSink) Close() error {
+	var firstErr error
+	for _, sink := range s.sinks {
+		err := sink.Close()
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+type discardOutputSink struct{}
+
+func (discardOutputSink) Write(Output) error {
+	return nil
+}
+
+func (discardOutputSink) Close() error {
+	return nil
+}
@@ -0,0 +1,145 @@
+package planner
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	OutputFormatICS = "ics"
+	// times are written without timezone (floating), so the calendar shows
+	// them on the same clock of the hour grade
+	layoutICSFloating = "20060102T150405"
+	layoutICSUTC      = "20060102T150405Z"
+	icsLineLimit      = 75
+	icsUIDDomain      = "gostudy"
+)
+
+// icsOutputSink writes one VEVENT per output. The UIDs come from the content IDs,
+// so importing the plan again updates the events instead of duplicating them.
+// The contents without ID of their own are identified by their discipline, subject
+// and title instead, as their positional IDs change when rows are inserted.
+type icsOutputSink struct {
+	file     io.WriteCloser
+	stamp    time.Time
+	uidCount map[string]int
+	err      error
+}
+
+func NewICSOutputSink(w io.WriteCloser) (OutputSink, error) {
+	s := &icsOutputSink{
+		file:     w,
+		stamp:    time.Now().UTC(),
+		uidCount: map[string]int{},
+	}
+	s.writeLines(
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//gostudy//planner//EN",
+		"CALSCALE:GREGORIAN",
+	)
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return s, nil
+}
+
+func (s *icsOutputSink) Write(output Output) error {
+	s.writeLines(
+		"BEGIN:VEVENT",
+		"UID:"+escapeICSText(s.uid(output.Discipline, output.Content)),
+		"DTSTAMP:"+s.stamp.Format(layoutICSUTC),
+		"DTSTART:"+output.Time.Format(layoutICSFloating),
+		"DTEND:"+output.Time.Add(output.Content.Duration).Format(layoutICSFloating),
+		"SUMMARY:"+escapeICSText(fmt.Sprintf("%s - %s", output.Discipline.Name, output.Content.Title)),
+		"DESCRIPTION:"+escapeICSText(fmt.Sprintf("Subject: %s\nReference: %s", output.Content.Subject, output.Content.Reference)),
+		"END:VEVENT",
+	)
+	return s.err
+}
+
+func (s *icsOutputSink) Close() error {
+	s.writeLines("END:VCALENDAR")
+	if s.err != nil {
+		s.file.Close()
+		return s.err
+	}
+
+	return s.file.Close()
+}
+
+// uid numbers the parts of a split content by their position on the split, so
+// they keep their UIDs when the plan changes. Other repeated IDs, like the ones
+// duplicated on the contents file, are numbered in order of appearance.
+func (s *icsOutputSink) uid(discipline *Discipline, content *Content) string {
+	id := content.ID
+	title := content.Title
+	part := 0
+	if content.split != nil {
+		// the titles of the parts have the number of parts, which may change
+		title = content.split.whole.Title
+		part = content.split.partNumber(content)
+	}
+
+	if content.positionalID {
+		// the titles of the reviews have their numbers already
+		id = fmt.Sprintf("%x", sha1.Sum([]byte(discipline.Name+"\x00"+content.Subject+"\x00"+title)))
+	}
+
+	if part > 1 {
+		id = fmt.Sprintf("%s-%d", id, part)
+	}
+
+	s.uidCount[id]++
+	if count := s.uidCount[id]; count > 1 {
+		return fmt.Sprintf("%s-repeated-%d@%s", id, count, icsUIDDomain)
+	}
+
+	return fmt.Sprintf("%s@%s", id, icsUIDDomain)
+}
+
+// writeLines keeps the first error, so the next writes are skipped.
+func (s *icsOutputSink) writeLines(lines ...string) {
+	for _, line := range lines {
+		if s.err != nil {
+			return
+		}
+
+		_, s.err = io.WriteString(s.file, foldICSLine(line)+"\r\n")
+	}
+}
+
+func escapeICSText(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, ",", `\,`, ";", `\;`, "\n", `\n`)
+	return replacer.Replace(value)
+}
+
+// foldICSLine breaks lines longer than 75 octets, without splitting a character.
+func foldICSLine(line string) string {
+	if len(line) <= icsLineLimit {
+		return line
+	}
+
+	var folded strings.Builder
+	limit := icsLineLimit
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+
+		folded.WriteString(line[:cut])
+		folded.WriteString("\r\n ")
+		line = line[cut:]
+		// the leading space counts on the continuation lines
+		limit = icsLineLimit - 1
+	}
+
+	folded.WriteString(line)
+	return folded.String()
+}
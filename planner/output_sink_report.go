@@ -0,0 +1,299 @@
+package planner
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	OutputFormatMarkdown = "md"
+	OutputFormatHTML     = "html"
+	reportTitle          = "Study plan"
+	layoutReportDay      = "Monday, 2006-01-02"
+)
+
+type reportInterval struct {
+	Start   time.Time
+	End     time.Time
+	Outputs []Output
+	// synthetic intervals are made from outputs without interval
+	synthetic bool
+}
+
+// reportDay groups the outputs of a date by interval, with the study time per discipline.
+type reportDay struct {
+	Date        time.Time
+	Intervals   []*reportInterval
+	Total       time.Duration
+	Disciplines []string
+	Durations   map[string]time.Duration
+}
+
+func newReportDay(date time.Time) *reportDay {
+	return &reportDay{
+		Date:        date,
+		Intervals:   make([]*reportInterval, 0),
+		Disciplines: make([]string, 0),
+		Durations:   map[string]time.Duration{},
+	}
+}
+
+func (d *reportDay) add(output Output) {
+	end := output.Time.Add(output.Content.Duration)
+	var last *reportInterval
+	if len(d.Intervals) > 0 {
+		last = d.Intervals[len(d.Intervals)-1]
+	}
+
+	switch {
+	case output.Interval != nil && last != nil && !last.synthetic &&
+		last.Start.Equal(output.Interval.Start) && last.End.Equal(output.Interval.End):
+		last.Outputs = append(last.Outputs, output)
+	case output.Interval == nil && last != nil && last.synthetic:
+		last.Outputs = append(last.Outputs, output)
+		if end.After(last.End) {
+			last.End = end
+		}
+	case output.Interval != nil:
+		d.Intervals = append(d.Intervals, &reportInterval{
+			Start:   output.Interval.Start,
+			End:     output.Interval.End,
+			Outputs: []Output{output},
+		})
+	default:
+		d.Intervals = append(d.Intervals, &reportInterval{
+			Start:     output.Time,
+			End:       end,
+			Outputs:   []Output{output},
+			synthetic: true,
+		})
+	}
+
+	name := output.Discipline.Name
+	if _, exists := d.Durations[name]; !exists {
+		d.Disciplines = append(d.Disciplines, name)
+	}
+
+	d.Durations[name] += output.Content.Duration
+	d.Total += output.Content.Duration
+}
+
+// totals describes the day total followed by the total of each discipline, in order of appearance.
+func (d *reportDay) totals() string {
+	parts := make([]string, len(d.Disciplines))
+	for index, name := range d.Disciplines {
+		parts[index] = fmt.Sprintf("%s %s", name, d.Durations[name])
+	}
+
+	return fmt.Sprintf("Total: %s (%s)", d.Total, strings.Join(parts, ", "))
+}
+
+// reportRenderer writes each part of a report, returning the first write error.
+type reportRenderer interface {
+	header(w io.Writer) error
+	day(w io.Writer, day *reportDay) error
+	footer(w io.Writer) error
+}
+
+// reportOutputSink writes a day of the plan as soon as the next day starts,
+// as the outputs come in order.
+type reportOutputSink struct {
+	file     io.WriteCloser
+	renderer reportRenderer
+	day      *reportDay
+}
+
+func newReportOutputSink(w io.WriteCloser, renderer reportRenderer) (OutputSink, error) {
+	err := renderer.header(w)
+	if err != nil {
+		return nil, err
+	}
+
+	return &reportOutputSink{file: w, renderer: renderer}, nil
+}
+
+// NewMarkdownOutputSink writes the plan as a Markdown document, one section per day.
+func NewMarkdownOutputSink(w io.WriteCloser) (OutputSink, error) {
+	return newReportOutputSink(w, markdownRenderer{})
+}
+
+// NewHTMLOutputSink writes the plan as a static HTML page, one section per day.
+func NewHTMLOutputSink(w io.WriteCloser) (OutputSink, error) {
+	return newReportOutputSink(w, htmlRenderer{})
+}
+
+func (s *reportOutputSink) Write(output Output) error {
+	// the contents after midnight of an overnight interval stay on the day it started
+	year, month, day := output.Time.Date()
+	if output.Interval != nil {
+		year, month, day = output.Interval.Start.Date()
+	}
+
+	if s.day != nil {
+		currentYear, currentMonth, currentDay := s.day.Date.Date()
+		if year != currentYear || month != currentMonth || day != currentDay {
+			err := s.renderer.day(s.file, s.day)
+			if err != nil {
+				return err
+			}
+
+			s.day = nil
+		}
+	}
+
+	if s.day == nil {
+		s.day = newReportDay(time.Date(year, month, day, 0, 0, 0, 0, output.Time.Location()))
+	}
+
+	s.day.add(output)
+	return nil
+}
+
+func (s *reportOutputSink) Close() error {
+	var err error
+	if s.day != nil {
+		err = s.renderer.day(s.file, s.day)
+	}
+
+	if err == nil {
+		err = s.renderer.footer(s.file)
+	}
+
+	if err != nil {
+		s.file.Close()
+		return err
+	}
+
+	return s.file.Close()
+}
+
+// referenceURL returns the reference when it's a web link, or empty otherwise.
+func referenceURL(reference string) string {
+	parsed, err := url.Parse(reference)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return ""
+	}
+
+	return reference
+}
+
+func formatReportInterval(start time.Time, end time.Time) string {
+	return fmt.Sprintf("%s - %s", start.Format(LayoutTimeOnly), end.Format(LayoutTimeOnly))
+}
+
+type markdownRenderer struct{}
+
+func (markdownRenderer) header(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "# %s\n", reportTitle)
+	return err
+}
+
+func (markdownRenderer) day(w io.Writer, day *reportDay) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n## %s\n", day.Date.Format(layoutReportDay))
+	for _, interval := range day.Intervals {
+		fmt.Fprintf(&b, "\n### %s\n\n", formatReportInterval(interval.Start, interval.End))
+		b.WriteString("| Time | Discipline | Subject | Title | Reference | Duration |\n")
+		b.WriteString("| --- | --- | --- | --- | --- | --- |\n")
+		for _, output := range interval.Outputs {
+			reference := escapeMarkdownCell(output.Content.Reference)
+			if link := referenceURL(output.Content.Reference); link != "" {
+				reference = fmt.Sprintf("[%s](<%s>)", reference, strings.ReplaceAll(link, ">", "%3E"))
+			}
+
+			fmt.Fprintf(
+				&b,
+				"| %s | %s | %s | %s | %s | %s |\n",
+				formatReportInterval(output.Time, output.Time.Add(output.Content.Duration)),
+				escapeMarkdownCell(output.Discipline.Name),
+				escapeMarkdownCell(output.Content.Subject),
+				escapeMarkdownCell(output.Content.Title),
+				reference,
+				output.Content.Duration,
+			)
+		}
+	}
+
+	fmt.Fprintf(&b, "\n**%s**\n", escapeMarkdownCell(day.totals()))
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (markdownRenderer) footer(w io.Writer) error {
+	return nil
+}
+
+// escapeMarkdownCell keeps the text inside its table cell and out of the Markdown syntax.
+func escapeMarkdownCell(value string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`, "|", `\|`, "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`,
+		"<", "&lt;", ">", "&gt;", "\r\n", " ", "\n", " ",
+	)
+	return replacer.Replace(value)
+}
+
+type htmlRenderer struct{}
+
+func (htmlRenderer) header(w io.Writer) error {
+	_, err := fmt.Fprintf(w, `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>%s</title>
+<style>
+body { font-family: sans-serif; margin: 1rem; }
+.interval { overflow-x: auto; }
+table { border-collapse: collapse; width: 100%%; }
+th, td { border: 1px solid #ccc; padding: 0.25rem 0.5rem; text-align: left; vertical-align: top; }
+th { background: #f0f0f0; }
+.total { font-weight: bold; }
+</style>
+</head>
+<body>
+<h1>%s</h1>
+`, reportTitle, reportTitle)
+	return err
+}
+
+func (htmlRenderer) day(w io.Writer, day *reportDay) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "<section>\n<h2>%s</h2>\n", day.Date.Format(layoutReportDay))
+	for _, interval := range day.Intervals {
+		fmt.Fprintf(&b, "<h3>%s</h3>\n", formatReportInterval(interval.Start, interval.End))
+		b.WriteString("<div class=\"interval\">\n<table>\n")
+		b.WriteString("<tr><th>Time</th><th>Discipline</th><th>Subject</th><th>Title</th><th>Reference</th><th>Duration</th></tr>\n")
+		for _, output := range interval.Outputs {
+			reference := html.EscapeString(output.Content.Reference)
+			if link := referenceURL(output.Content.Reference); link != "" {
+				reference = fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(link), reference)
+			}
+
+			fmt.Fprintf(
+				&b,
+				"<tr><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>\n",
+				formatReportInterval(output.Time, output.Time.Add(output.Content.Duration)),
+				html.EscapeString(output.Discipline.Name),
+				html.EscapeString(output.Content.Subject),
+				html.EscapeString(output.Content.Title),
+				reference,
+				output.Content.Duration,
+			)
+		}
+
+		b.WriteString("</table>\n</div>\n")
+	}
+
+	fmt.Fprintf(&b, "<p class=\"total\">%s</p>\n</section>\n", html.EscapeString(day.totals()))
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (htmlRenderer) footer(w io.Writer) error {
+	_, err := io.WriteString(w, "</body>\n</html>\n")
+	return err
+}
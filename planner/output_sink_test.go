@@ -0,0 +1,270 @@
+package planner_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kaiquegarcia/gostudy/v2/planner"
+	"github.com/stretchr/testify/assert"
+)
+
+type bufferCloser struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (b *bufferCloser) Close() error {
+	b.closed = true
+	return nil
+}
+
+func Test_OutputSink(t *testing.T) {
+	output := planner.Output{
+		Time:       time.Date(2024, 2, 21, 19, 0, 0, 0, time.UTC),
+		Discipline: &planner.Discipline{Name: "Math"},
+		Content:    &planner.Content{ID: "math-1", Subject: "Logic", Title: "Sets", Duration: 30 * time.Minute},
+	}
+
+	t.Run("NewMultiOutputSink should fan out to every sink", func(t *testing.T) {
+		// Arrange
+		memory := planner.NewMemoryOutputSink()
+		file := &bufferCloser{}
+		csvSink, err := planner.NewCSVOutputSink(file)
+		if !assert.Nil(t, err, "err from NewCSVOutputSink should be nil") {
+			t.FailNow()
+		}
+		sink := planner.NewMultiOutputSink(memory, csvSink)
+
+		// Act
+		writeErr := sink.Write(output)
+		closeErr := sink.Close()
+
+		// Assert
+		assert.Nil(t, writeErr, "err from Write should be nil")
+		assert.Nil(t, closeErr, "err from Close should be nil")
+		assert.Equal(t, []planner.Output{output}, memory.Outputs)
+		assert.Equal(t, "Datetime,Discipline,Subject,Title,Reference,Duration\n2024-02-21T19:00:00Z,Math,Logic,Sets,,30m0s\n", file.String())
+		assert.True(t, file.closed, "the csv file should be closed")
+	})
+
+	t.Run("the json sink should write a valid array", func(t *testing.T) {
+		for _, count := range []int{0, 2} {
+			// Arrange
+			file := &bufferCloser{}
+			sink, err := planner.NewOutputSink(planner.OutputFormatJSON, file)
+			if !assert.Nil(t, err, "err from NewOutputSink should be nil") {
+				t.FailNow()
+			}
+
+			// Act
+			for index := 0; index < count; index++ {
+				sink.Write(output)
+			}
+			sink.Close()
+
+			// Assert
+			var decoded []map[string]string
+			assert.Nil(t, json.Unmarshal(file.Bytes(), &decoded), "the output should be valid json")
+			assert.Len(t, decoded, count)
+			if count > 0 {
+				assert.Equal(t, "math-1", decoded[0]["id"], "id should be 'math-1'")
+			}
+		}
+	})
+
+	t.Run("the ics sink should write one event per output with stable UIDs", func(t *testing.T) {
+		// Arrange
+		file := &bufferCloser{}
+		sink, err := planner.NewOutputSink(planner.OutputFormatICS, file)
+		if !assert.Nil(t, err, "err from NewOutputSink should be nil") {
+			t.FailNow()
+		}
+		long := output
+		long.Content = &planner.Content{
+			ID:        "math-1",
+			Subject:   "Logic",
+			Title:     "Sets, relations; and a title long enough to be folded on the calendar file",
+			Duration:  30 * time.Minute,
+			Reference: "book",
+		}
+
+		// Act
+		sink.Write(output)
+		sink.Write(long)
+		sink.Close()
+
+		// Assert
+		ics := file.String()
+		assert.Equal(t, 2, strings.Count(ics, "BEGIN:VEVENT"), "should have 2 events")
+		assert.Contains(t, ics, "UID:math-1@gostudy\r\n")
+		assert.Contains(t, ics, "UID:math-1-repeated-2@gostudy\r\n", "repeated IDs should be numbered")
+		assert.Contains(t, ics, "DTSTART:20240221T190000\r\nDTEND:20240221T193000\r\n")
+		assert.Contains(t, ics, "SUMMARY:Math - Sets\\, relations\\; and")
+		assert.Contains(t, ics, "DESCRIPTION:Subject: Logic\\nReference: book\r\n")
+		for _, line := range strings.Split(ics, "\r\n") {
+			assert.LessOrEqual(t, len(line), 75, "lines should be folded")
+		}
+		assert.True(t, strings.HasSuffix(ics, "END:VCALENDAR\r\n"), "the calendar should be closed")
+	})
+
+	t.Run("the ics sink should keep the UIDs of the contents without ID when rows are inserted", func(t *testing.T) {
+		// Arrange
+		hg := testHourGrade(t, "14:00-15:00")
+		disciplineRows := [][]string{
+			{"Name", "Filename", "Daily Limit", "Content Gap", "Subject Gap"},
+			{"Math", "math.csv", "01:00:00", "00:00:00", "00:00:00"},
+		}
+		before := testDisciplines(t, disciplineRows, map[string][][]string{
+			"math.csv": {{"Logic", "Sets", "00:30:00"}},
+		})
+		after := testDisciplines(t, disciplineRows, map[string][][]string{
+			"math.csv": {{"Logic", "Intro", "00:30:00"}, {"Logic", "Sets", "00:30:00"}},
+		})
+
+		// Act
+		beforeUIDs := mountICSUIDs(t, hg, before)
+		afterUIDs := mountICSUIDs(t, hg, after)
+
+		// Assert
+		assert.NotEmpty(t, beforeUIDs["Math - Sets"], "sets should have an UID")
+		assert.Equal(t, beforeUIDs["Math - Sets"], afterUIDs["Math - Sets"], "sets should keep its UID")
+		assert.NotEqual(t, afterUIDs["Math - Intro"], afterUIDs["Math - Sets"], "the contents should have different UIDs")
+	})
+
+	t.Run("the ics sink should keep the UIDs of the parts when a replan splits the content in fewer parts", func(t *testing.T) {
+		// Arrange
+		disciplineHeader := []string{"Name", "Filename", "Daily Limit", "Content Gap", "Subject Gap", "Splittable"}
+		contents := map[string][][]string{
+			"math.csv":    {{"Logic", "Lecture", "02:30:00", "math-1", ""}},
+			"history.csv": {{"Ancient", "Lecture", "02:30:00"}},
+		}
+		plan := testDisciplines(t, [][]string{
+			disciplineHeader,
+			{"Math", "math.csv", "01:00:00", "00:00:00", "00:00:00", "yes"},
+			{"History", "history.csv", "01:00:00", "00:00:00", "00:00:00", "yes"},
+		}, contents)
+		replan := testDisciplines(t, [][]string{
+			disciplineHeader,
+			{"Math", "math.csv", "02:00:00", "00:00:00", "00:00:00", "yes"},
+			{"History", "history.csv", "02:00:00", "00:00:00", "00:00:00", "yes"},
+		}, contents)
+
+		// Act
+		planUIDs := mountICSUIDs(t, testHourGrade(t, "14:00-16:00"), plan)
+		replanUIDs := mountICSUIDs(t, testHourGrade(t, "14:00-18:00"), replan)
+
+		// Assert
+		assert.Equal(t, "math-1@gostudy", planUIDs["Math - Lecture (part 1/3)"])
+		assert.Equal(t, "math-1-2@gostudy", planUIDs["Math - Lecture (part 2/3)"])
+		assert.Equal(t, "math-1-3@gostudy", planUIDs["Math - Lecture (part 3/3)"])
+		for _, discipline := range []string{"Math", "History"} {
+			for part := 1; part <= 2; part++ {
+				uid := planUIDs[fmt.Sprintf("%s - Lecture (part %d/3)", discipline, part)]
+				assert.NotEmpty(t, uid, "part %d of %s should have an UID", part, discipline)
+				assert.Equal(t, uid, replanUIDs[fmt.Sprintf("%s - Lecture (part %d/2)", discipline, part)], "part %d of %s should keep its UID", part, discipline)
+			}
+		}
+	})
+
+	t.Run("the markdown sink should group the outputs by day and interval", func(t *testing.T) {
+		// Arrange
+		file := &bufferCloser{}
+		sink, err := planner.NewOutputSink(planner.OutputFormatMarkdown, file)
+		if !assert.Nil(t, err, "err from NewOutputSink should be nil") {
+			t.FailNow()
+		}
+		interval := &planner.HourGradeInterval{
+			Start: time.Date(2024, 2, 21, 19, 0, 0, 0, time.UTC),
+			End:   time.Date(2024, 2, 21, 21, 0, 0, 0, time.UTC),
+		}
+		first := output
+		first.Interval = interval
+		second := first
+		second.Time = first.Time.Add(40 * time.Minute)
+		second.Content = &planner.Content{Subject: "Logic", Title: "Sets | Relations", Duration: 20 * time.Minute, Reference: "https://example.com/sets"}
+		nextDay := output
+		nextDay.Time = output.Time.AddDate(0, 0, 1)
+
+		// Act
+		sink.Write(first)
+		sink.Write(second)
+		sink.Write(nextDay)
+		closeErr := sink.Close()
+
+		// Assert
+		md := file.String()
+		assert.Nil(t, closeErr, "err from Close should be nil")
+		assert.Contains(t, md, "## Wednesday, 2024-02-21\n\n### 19:00 - 21:00\n")
+		assert.Contains(t, md, "| 19:00 - 19:30 | Math | Logic | Sets |  | 30m0s |\n")
+		assert.Contains(t, md, "| 19:40 - 20:00 | Math | Logic | Sets \\| Relations | [https://example.com/sets](<https://example.com/sets>) | 20m0s |\n")
+		assert.Contains(t, md, "**Total: 50m0s (Math 50m0s)**")
+		assert.Contains(t, md, "## Thursday, 2024-02-22\n\n### 19:00 - 19:30\n", "outputs without interval should use their own times")
+		assert.True(t, file.closed, "the file should be closed")
+	})
+
+	t.Run("the html sink should escape the contents and link the references", func(t *testing.T) {
+		// Arrange
+		file := &bufferCloser{}
+		sink, err := planner.NewOutputSink(planner.OutputFormatHTML, file)
+		if !assert.Nil(t, err, "err from NewOutputSink should be nil") {
+			t.FailNow()
+		}
+		linked := output
+		linked.Content = &planner.Content{Subject: "<Logic>", Title: "Sets & Relations", Duration: 20 * time.Minute, Reference: "https://example.com/?a=1&b=2"}
+		unsafe := output
+		unsafe.Content = &planner.Content{Title: "Script", Duration: 10 * time.Minute, Reference: "javascript:alert(1)"}
+
+		// Act
+		sink.Write(linked)
+		sink.Write(unsafe)
+		sink.Close()
+
+		// Assert
+		page := file.String()
+		assert.Contains(t, page, "<h2>Wednesday, 2024-02-21</h2>")
+		assert.Contains(t, page, "<td>&lt;Logic&gt;</td><td>Sets &amp; Relations</td>")
+		assert.Contains(t, page, `<a href="https://example.com/?a=1&amp;b=2">https://example.com/?a=1&amp;b=2</a>`)
+		assert.Contains(t, page, "<td>javascript:alert(1)</td>", "only web references should be links")
+		assert.Contains(t, page, `<p class="total">Total: 30m0s (Math 30m0s)</p>`)
+		assert.True(t, strings.HasSuffix(page, "</html>\n"), "the page should be closed")
+	})
+
+	t.Run("NewOutputSink should refuse unknown formats", func(t *testing.T) {
+		// Act
+		_, err := planner.NewOutputSink("xml", &bufferCloser{})
+
+		// Assert
+		assert.ErrorIs(t, err, planner.ErrUnknownOutputFormat)
+		assert.False(t, planner.IsOutputFormat("xml"), "xml shouldn't be a known format")
+		assert.True(t, planner.IsOutputFormat(planner.OutputFormatICS), "ics should be a known format")
+	})
+}
+
+// mountICSUIDs returns the UIDs of the ICS events, keyed by their summaries.
+func mountICSUIDs(t *testing.T, hg *planner.HourGrade, disciplines []*planner.Discipline) map[string]string {
+	file := &bufferCloser{}
+	sink, err := planner.NewOutputSink(planner.OutputFormatICS, file)
+	if !assert.Nil(t, err, "err from NewOutputSink should be nil") {
+		t.FailNow()
+	}
+	maker := planner.NewMakerWithSink(testLogger(), hg, disciplines, testStartDate, sink)
+	if !assert.Nil(t, mountWithin(t, maker), "err from Mount should be nil") || !assert.Nil(t, maker.Close(), "err from Close should be nil") {
+		t.FailNow()
+	}
+
+	uids := map[string]string{}
+	uid := ""
+	for _, line := range strings.Split(file.String(), "\r\n") {
+		if strings.HasPrefix(line, "UID:") {
+			uid = strings.TrimPrefix(line, "UID:")
+		} else if strings.HasPrefix(line, "SUMMARY:") {
+			uids[strings.TrimPrefix(line, "SUMMARY:")] = uid
+		}
+	}
+
+	return uids
+}
@@ -1,36 +1,135 @@
 package planner
 
 import (
+	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
 )
 
-func parseDuration(duration string) (time.Duration, error) {
-	pieces := strings.Split(duration, ":")
-	if len(pieces) != 3 {
+// DurationMode chooses how the durations with only two pieces are read.
+type DurationMode string
+
+const (
+	// DurationModeHoursMinutes reads two pieces durations, like 00:45, as hh:mm
+	DurationModeHoursMinutes DurationMode = "hh:mm"
+	// DurationModeMinutesSeconds reads two pieces durations, like 45:10, as mm:ss (like the video players)
+	DurationModeMinutesSeconds DurationMode = "mm:ss"
+)
+
+var isoDurationRegexp = regexp.MustCompile(`^P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:[.,]\d+)?)S)?)?$`)
+
+func ParseDurationMode(value string) (DurationMode, error) {
+	mode := DurationMode(value)
+	if mode != DurationModeHoursMinutes && mode != DurationModeMinutesSeconds {
+		return "", fmt.Errorf("%w: %q", ErrUnknownDurationMode, value)
+	}
+
+	return mode, nil
+}
+
+// ParseDuration reads the durations written as:
+//   - hh:mm:ss, like 01:30:00 or 1:30:00;
+//   - hh:mm or mm:ss, like 00:45, depending on WithShortDurationMode;
+//   - Go durations, like 90m or 1h30m;
+//   - ISO 8601 durations, like PT1H30M.
+func ParseDuration(value string, options ...InputOption) (time.Duration, error) {
+	settings := newInputSettings(options)
+	value = strings.TrimSpace(value)
+	var (
+		duration time.Duration
+		err      error
+	)
+	switch {
+	case strings.Contains(value, ":"):
+		duration, err = parseClockDuration(value, settings.shortMode)
+	case strings.HasPrefix(strings.ToUpper(value), "P"):
+		duration, err = parseISODuration(strings.ToUpper(value))
+	default:
+		duration, err = time.ParseDuration(value)
+		if err == nil && duration < 0 {
+			err = ErrInvalidDurationFormat
+		}
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidDurationFormat, value)
+	}
+
+	return duration, nil
+}
+
+// parseClockDuration reads hh:mm:ss, hh:mm or mm:ss. Only the first piece may
+// have any number of digits, the others have two digits each.
+func parseClockDuration(value string, shortMode DurationMode) (time.Duration, error) {
+	pieces := strings.Split(value, ":")
+	if len(pieces) < 2 || len(pieces) > 3 {
 		return 0, ErrInvalidDurationFormat
 	}
-	for _, p := range pieces {
-		if len(p) != 2 {
+
+	numbers := make([]int, len(pieces))
+	for index, piece := range pieces {
+		if piece == "" || (index > 0 && len(piece) != 2) {
 			return 0, ErrInvalidDurationFormat
 		}
+
+		for _, digit := range piece {
+			if digit < '0' || digit > '9' {
+				return 0, ErrInvalidDurationFormat
+			}
+		}
+
+		numbers[index], _ = strconv.Atoi(piece)
 	}
 
-	hours, err := strconv.Atoi(pieces[0])
-	if err != nil {
-		return 0, err
+	if len(numbers) == 3 {
+		return (time.Duration(numbers[0]) * time.Hour) + (time.Duration(numbers[1]) * time.Minute) + (time.Duration(numbers[2]) * time.Second), nil
 	}
 
-	minutes, err := strconv.Atoi(pieces[1])
-	if err != nil {
-		return 0, err
+	if numbers[1] >= 60 {
+		return 0, ErrInvalidDurationFormat
 	}
 
-	seconds, err := strconv.Atoi(pieces[2])
-	if err != nil {
-		return 0, err
+	if shortMode == DurationModeMinutesSeconds {
+		return (time.Duration(numbers[0]) * time.Minute) + (time.Duration(numbers[1]) * time.Second), nil
+	}
+
+	return (time.Duration(numbers[0]) * time.Hour) + (time.Duration(numbers[1]) * time.Minute), nil
+}
+
+// parseISODuration reads weeks, days, hours, minutes and seconds, where days
+// always last 24 hours. Years and months aren't accepted, as their lengths vary.
+func parseISODuration(value string) (time.Duration, error) {
+	matches := isoDurationRegexp.FindStringSubmatch(value)
+	if matches == nil || value == "P" || strings.HasSuffix(value, "T") {
+		return 0, ErrInvalidDurationFormat
 	}
 
-	return (time.Duration(hours) * time.Hour) + (time.Duration(minutes) * time.Minute) + (time.Duration(seconds) * time.Second), nil
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var duration time.Duration = 0
+	for index, unit := range units {
+		match := matches[index+1]
+		if match == "" {
+			continue
+		}
+
+		amount, err := strconv.ParseFloat(strings.Replace(match, ",", ".", 1), 64)
+		if err != nil {
+			return 0, err
+		}
+
+		duration += time.Duration(amount * float64(unit))
+	}
+
+	return duration, nil
+}
+
+// FormatDuration writes the duration back on the hh:mm:ss pattern read by ParseDuration.
+func FormatDuration(duration time.Duration) string {
+	duration = duration.Round(time.Second)
+	hours := duration / time.Hour
+	minutes := (duration % time.Hour) / time.Minute
+	seconds := (duration % time.Minute) / time.Second
+	return fmt.Sprintf("%02d:%02d:%02d", hours, minutes, seconds)
 }
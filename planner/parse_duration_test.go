@@ -0,0 +1,101 @@
+package planner_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaiquegarcia/gostudy/v2/planner"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseDuration(t *testing.T) {
+	t.Run("should accept every supported syntax", func(t *testing.T) {
+		cases := map[string]time.Duration{
+			"01:30:00":  90 * time.Minute,
+			"1:05:00":   65 * time.Minute,
+			"100:00:00": 100 * time.Hour,
+			"00:45":     45 * time.Minute,
+			"90m":       90 * time.Minute,
+			"1h30m":     90 * time.Minute,
+			" 45s ":     45 * time.Second,
+			"PT1H30M":   90 * time.Minute,
+			"pt45m10s":  45*time.Minute + 10*time.Second,
+			"P1DT2H":    26 * time.Hour,
+			"PT0.5S":    500 * time.Millisecond,
+			"P1W":       7 * 24 * time.Hour,
+			"0:00:00":   0,
+		}
+
+		for value, expected := range cases {
+			// Act
+			duration, err := planner.ParseDuration(value)
+
+			// Assert
+			assert.Nil(t, err, "err from ParseDuration(%q) should be nil", value)
+			assert.Equal(t, expected, duration, "ParseDuration(%q)", value)
+		}
+	})
+
+	t.Run("should read two pieces as mm:ss when configured", func(t *testing.T) {
+		// Act
+		duration, err := planner.ParseDuration("45:10", planner.WithShortDurationMode(planner.DurationModeMinutesSeconds))
+
+		// Assert
+		assert.Nil(t, err, "err from ParseDuration should be nil")
+		assert.Equal(t, 45*time.Minute+10*time.Second, duration)
+	})
+
+	t.Run("should read each discipline list on its own mode", func(t *testing.T) {
+		// Arrange
+		disciplineRows := [][]string{
+			{"Name", "Filename", "Daily Limit", "Content Gap", "Subject Gap"},
+			{"Math", "math.csv", "01:00:00", "00:00:00", "00:00:00"},
+		}
+		contents := map[string][][]string{
+			"math.csv": {{"Subject", "Title", "Duration"}, {"Logic", "Sets", "45:10"}},
+		}
+		videos, err := planner.NewDisciplineFromRowsWithContents(disciplineRows, contents, planner.WithShortDurationMode(planner.DurationModeMinutesSeconds))
+		if !assert.Nil(t, err, "err from NewDisciplineFromRowsWithContents should be nil") {
+			t.FailNow()
+		}
+		defer videos[0].Close()
+
+		hours, err := planner.NewDisciplineFromRowsWithContents(disciplineRows, contents)
+		if !assert.Nil(t, err, "err from NewDisciplineFromRowsWithContents should be nil") {
+			t.FailNow()
+		}
+		defer hours[0].Close()
+
+		// Act
+		videoContent, videoErr := videos[0].Next()
+		hourContent, hourErr := hours[0].Next()
+
+		// Assert
+		assert.Nil(t, videoErr, "err from Next should be nil")
+		assert.Nil(t, hourErr, "err from Next should be nil")
+		assert.Equal(t, 45*time.Minute+10*time.Second, videoContent.Duration)
+		assert.Equal(t, 45*time.Hour+10*time.Minute, hourContent.Duration)
+		assert.Equal(t, time.Hour, videos[0].DailyLimit, "the hh:mm:ss durations should not change")
+	})
+
+	t.Run("should quote the invalid values", func(t *testing.T) {
+		for _, value := range []string{"", "abc", "1:5:00", "00:60", "1:00:00:00", "-1h", "P", "PT", "P1M", "PT1H30", "12"} {
+			// Act
+			_, err := planner.ParseDuration(value)
+
+			// Assert
+			assert.ErrorIs(t, err, planner.ErrInvalidDurationFormat, "ParseDuration(%q) should fail", value)
+			if err != nil {
+				assert.Contains(t, err.Error(), `"`+value+`"`)
+			}
+		}
+	})
+
+	t.Run("ParseDurationMode should refuse unknown modes", func(t *testing.T) {
+		// Act
+		_, err := planner.ParseDurationMode("ss:mm")
+
+		// Assert
+		assert.ErrorIs(t, err, planner.ErrUnknownDurationMode)
+	})
+}
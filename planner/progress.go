@@ -0,0 +1,104 @@
+package planner
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	ProgressDone    = "done"
+	ProgressSkipped = "skipped"
+)
+
+type ProgressEntry struct {
+	ID             string
+	CompletedAt    time.Time
+	ActualDuration time.Duration
+	Status         string
+}
+
+// Progress is the completion log of the contents (and reviews) already studied
+// or given up, indexed by their IDs.
+type Progress struct {
+	entries map[string]*ProgressEntry
+}
+
+func NewProgress() *Progress {
+	return &Progress{entries: map[string]*ProgressEntry{}}
+}
+
+func (pg *Progress) Add(entry *ProgressEntry) {
+	pg.entries[entry.ID] = entry
+}
+
+// Get returns nil when there's no progress for the ID.
+func (pg *Progress) Get(id string) *ProgressEntry {
+	if pg == nil {
+		return nil
+	}
+
+	return pg.entries[id]
+}
+
+func (pg *Progress) Entries() []*ProgressEntry {
+	if pg == nil {
+		return make([]*ProgressEntry, 0)
+	}
+
+	entries := make([]*ProgressEntry, 0, len(pg.entries))
+	for _, entry := range pg.entries {
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ID < entries[j].ID
+	})
+
+	return entries
+}
+
+func NewProgressFromRows(rows [][]string, options ...InputOption) (*Progress, error) {
+	progress := NewProgress()
+	for line := 1; line < len(rows); line++ {
+		columns := rows[line]
+		if len(columns) == 0 || columns[0] == "" {
+			break
+		}
+		// ID, [Completed At], [Actual Duration], [Status]
+		if len(columns) > 4 {
+			return nil, ErrUnexpectedColumnsLength
+		}
+
+		entry := &ProgressEntry{
+			ID:     strings.TrimSpace(columns[0]),
+			Status: ProgressDone,
+		}
+
+		var err error
+		if len(columns) > 1 && columns[1] != "" {
+			entry.CompletedAt, err = time.Parse(LayoutDateOnly, columns[1])
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if len(columns) > 2 && columns[2] != "" {
+			entry.ActualDuration, err = ParseDuration(columns[2], options...)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if len(columns) > 3 && columns[3] != "" {
+			entry.Status = strings.ToLower(strings.TrimSpace(columns[3]))
+			if entry.Status != ProgressDone && entry.Status != ProgressSkipped {
+				return nil, ErrInvalidProgressStatus
+			}
+		}
+
+		progress.Add(entry)
+	}
+
+	return progress, nil
+}
@@ -0,0 +1,224 @@
+package planner
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Project holds the hour grade, the disciplines and their contents on a single
+// file, as an alternative to the CSV files.
+type Project struct {
+	HourGrade   map[string][]string  `yaml:"hour_grade" json:"hour_grade"`
+	Disciplines []*ProjectDiscipline `yaml:"disciplines" json:"disciplines"`
+	// dir resolves the content files relative to the project file
+	dir string
+}
+
+// ProjectDiscipline has the same fields of disciplines.csv. The contents are
+// either written inline or referenced by a CSV File.
+type ProjectDiscipline struct {
+	Name            string            `yaml:"name" json:"name"`
+	File            string            `yaml:"file" json:"file"`
+	DailyLimit      string            `yaml:"daily_limit" json:"daily_limit"`
+	ContentGap      string            `yaml:"content_gap" json:"content_gap"`
+	SubjectGap      string            `yaml:"subject_gap" json:"subject_gap"`
+	Weight          int               `yaml:"weight" json:"weight"`
+	Deadline        string            `yaml:"deadline" json:"deadline"`
+	Splittable      bool              `yaml:"splittable" json:"splittable"`
+	ReviewIntervals []int             `yaml:"review_intervals" json:"review_intervals"`
+	ReviewDuration  string            `yaml:"review_duration" json:"review_duration"`
+	WeeklyLimit     string            `yaml:"weekly_limit" json:"weekly_limit"`
+	MinWeekly       string            `yaml:"min_weekly" json:"min_weekly"`
+	Weekdays        []string          `yaml:"weekdays" json:"weekdays"`
+	TimeWindows     []string          `yaml:"time_windows" json:"time_windows"`
+	Reorderable     bool              `yaml:"reorderable" json:"reorderable"`
+	Contents        []*ProjectContent `yaml:"contents" json:"contents"`
+}
+
+type ProjectContent struct {
+	ID            string   `yaml:"id" json:"id"`
+	Subject       string   `yaml:"subject" json:"subject"`
+	Title         string   `yaml:"title" json:"title"`
+	Duration      string   `yaml:"duration" json:"duration"`
+	Reference     string   `yaml:"reference" json:"reference"`
+	Prerequisites []string `yaml:"prerequisites" json:"prerequisites"`
+}
+
+// LoadProject reads YAML (.yaml or .yml) or JSON (.json) project files.
+// Unknown fields are refused, as they're probably typos.
+func LoadProject(filename string) (*Project, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	project := &Project{dir: filepath.Dir(filename)}
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(project)
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(project)
+	default:
+		return nil, ErrUnknownProjectFormat
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+// HourGradeRows writes the hour grade as the rows of hour_grade.csv. The keys
+// may be weekday names or the weekdays and weekends aliases.
+func (pj *Project) HourGradeRows() ([][]string, error) {
+	rows := make([][]string, 8)
+	rows[0] = []string{"Day of Week"}
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		rows[weekday+1] = []string{strings.ToUpper(weekday.String())}
+	}
+
+	for key, intervals := range pj.HourGrade {
+		weekdays, err := parseWeekdays(key)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, weekday := range weekdays {
+			rows[weekday+1] = append(rows[weekday+1], intervals...)
+		}
+	}
+
+	return rows, nil
+}
+
+// DisciplineRows writes the disciplines as the rows of disciplines.csv. The
+// disciplines with inline contents are named by their own names instead of filenames,
+// so their names must not repeat nor match the files of the other disciplines.
+func (pj *Project) DisciplineRows() ([][]string, error) {
+	rows := [][]string{{
+		"Name", "Filename", "Daily Limit", "Content Gap", "Subject Gap",
+		ColumnWeight, ColumnDeadline, ColumnSplittable, ColumnReviewIntervals, ColumnReviewDuration,
+		ColumnWeeklyLimit, ColumnMinWeekly, ColumnWeekdays, ColumnTimeWindows, ColumnReorderable,
+	}}
+	inlineNames := map[string]bool{}
+	files := map[string]bool{}
+	for _, pd := range pj.Disciplines {
+		filename, err := pj.filename(pd)
+		if err != nil {
+			return nil, err
+		}
+
+		if inlineNames[filename] || (len(pd.Contents) > 0 && files[filename]) {
+			return nil, ErrDuplicateProjectContents
+		}
+
+		if len(pd.Contents) > 0 {
+			inlineNames[filename] = true
+		} else {
+			files[filename] = true
+		}
+
+		weight := ""
+		if pd.Weight != 0 {
+			weight = strconv.Itoa(pd.Weight)
+		}
+
+		reviewIntervals := make([]string, len(pd.ReviewIntervals))
+		for index, days := range pd.ReviewIntervals {
+			reviewIntervals[index] = strconv.Itoa(days)
+		}
+
+		rows = append(rows, []string{
+			pd.Name,
+			filename,
+			pd.DailyLimit,
+			durationOrZero(pd.ContentGap),
+			durationOrZero(pd.SubjectGap),
+			weight,
+			pd.Deadline,
+			formatBool(pd.Splittable),
+			strings.Join(reviewIntervals, reviewListSeparator),
+			pd.ReviewDuration,
+			pd.WeeklyLimit,
+			pd.MinWeekly,
+			strings.Join(pd.Weekdays, weekdayListSeparator),
+			strings.Join(pd.TimeWindows, timeWindowListSeparator),
+			formatBool(pd.Reorderable),
+		})
+	}
+
+	return rows, nil
+}
+
+// ContentRows writes the inline contents as the rows of the content files,
+// indexed by the filenames used on DisciplineRows.
+func (pj *Project) ContentRows() map[string][][]string {
+	contents := map[string][][]string{}
+	for _, pd := range pj.Disciplines {
+		if len(pd.Contents) == 0 {
+			continue
+		}
+
+		rows := [][]string{contentColumns}
+		for _, pc := range pd.Contents {
+			rows = append(rows, []string{
+				pc.Subject,
+				pc.Title,
+				pc.Duration,
+				pc.Reference,
+				pc.ID,
+				strings.Join(pc.Prerequisites, idListSeparator),
+			})
+		}
+
+		contents[pd.Name] = rows
+	}
+
+	return contents
+}
+
+func (pj *Project) filename(pd *ProjectDiscipline) (string, error) {
+	hasFile := pd.File != ""
+	hasContents := len(pd.Contents) > 0
+	if hasFile == hasContents {
+		return "", ErrInvalidProjectDiscipline
+	}
+
+	if hasContents {
+		return pd.Name, nil
+	}
+
+	if filepath.IsAbs(pd.File) {
+		return pd.File, nil
+	}
+
+	return filepath.Join(pj.dir, pd.File), nil
+}
+
+func durationOrZero(value string) string {
+	if value == "" {
+		return "00:00:00"
+	}
+
+	return value
+}
+
+func formatBool(value bool) string {
+	if value {
+		return "yes"
+	}
+
+	return "no"
+}
@@ -0,0 +1,168 @@
+package planner_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kaiquegarcia/gostudy/v2/planner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const projectYAML = `
+hour_grade:
+  weekdays: ["14:00-17:00"]
+disciplines:
+  - name: English
+    daily_limit: "00:30:00"
+    content_gap: "00:05:00"
+    review_intervals: [1, 3]
+    contents:
+      - id: english-1
+        subject: Grammar
+        title: Verbs
+        duration: "00:10:00"
+      - subject: Grammar
+        title: Nouns
+        duration: "00:15:00"
+        prerequisites: [english-1]
+`
+
+func writeProject(t *testing.T, name string, content string) string {
+	filename := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(filename, []byte(content), 0o644))
+	return filename
+}
+
+func Test_Project(t *testing.T) {
+	t.Run("should build the hour grade and the disciplines with inline contents", func(t *testing.T) {
+		// Arrange
+		project, err := planner.LoadProject(writeProject(t, "gostudy.yaml", projectYAML))
+		require.NoError(t, err)
+
+		// Act
+		hourGradeRows, err := project.HourGradeRows()
+		require.NoError(t, err)
+		disciplineRows, err := project.DisciplineRows()
+		require.NoError(t, err)
+		hourGrade, hourGradeErr := planner.NewHourGradeFromRow(hourGradeRows)
+		disciplines, disciplinesErr := planner.NewDisciplineFromRowsWithContents(disciplineRows, project.ContentRows())
+
+		// Assert
+		require.NoError(t, hourGradeErr)
+		require.NoError(t, disciplinesErr)
+		require.Len(t, disciplines, 1)
+		defer disciplines[0].Close()
+
+		assert.Len(t, hourGrade.Weekdays[time.Monday], 1)
+		assert.Len(t, hourGrade.Weekdays[time.Sunday], 0)
+		assert.Equal(t, "English", disciplines[0].Name)
+		assert.Equal(t, 30*time.Minute, disciplines[0].DailyLimit)
+		assert.Equal(t, []int{1, 3}, disciplines[0].ReviewIntervals)
+
+		first, err := disciplines[0].Next()
+		require.NoError(t, err)
+		assert.Equal(t, "english-1", first.ID)
+		assert.Equal(t, 10*time.Minute, first.Duration)
+
+		second, err := disciplines[0].Next()
+		require.NoError(t, err)
+		assert.Equal(t, "English#2", second.ID)
+		assert.Equal(t, []string{"english-1"}, second.Prerequisites)
+	})
+
+	t.Run("should read JSON projects", func(t *testing.T) {
+		// Arrange
+		filename := writeProject(t, "gostudy.json", `{"hour_grade": {"monday": ["08:00-09:00"]}, "disciplines": [{"name": "Math", "file": "math.csv", "daily_limit": "01:00:00"}]}`)
+
+		// Act
+		project, err := planner.LoadProject(filename)
+
+		// Assert
+		require.NoError(t, err)
+		rows, err := project.DisciplineRows()
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(filepath.Dir(filename), "math.csv"), rows[1][1])
+	})
+
+	t.Run("should refuse unknown fields", func(t *testing.T) {
+		// Act
+		_, err := planner.LoadProject(writeProject(t, "gostudy.yaml", "hour_grade: {}\ndisciplnes: []\n"))
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("should refuse disciplines with both file and contents", func(t *testing.T) {
+		// Arrange
+		project, err := planner.LoadProject(writeProject(t, "gostudy.yaml", `
+disciplines:
+  - name: Math
+    file: math.csv
+    daily_limit: "01:00:00"
+    contents:
+      - {subject: A, title: B, duration: "00:10:00"}
+`))
+		require.NoError(t, err)
+
+		// Act
+		_, err = project.DisciplineRows()
+
+		// Assert
+		assert.ErrorIs(t, err, planner.ErrInvalidProjectDiscipline)
+	})
+
+	t.Run("should refuse disciplines with inline contents and the same name", func(t *testing.T) {
+		// Arrange
+		project, err := planner.LoadProject(writeProject(t, "gostudy.yaml", `
+disciplines:
+  - name: Math
+    daily_limit: "01:00:00"
+    contents:
+      - {subject: A, title: B, duration: "00:10:00"}
+  - name: Math
+    daily_limit: "01:00:00"
+    contents:
+      - {subject: C, title: D, duration: "00:10:00"}
+`))
+		require.NoError(t, err)
+
+		// Act
+		_, err = project.DisciplineRows()
+
+		// Assert
+		assert.ErrorIs(t, err, planner.ErrDuplicateProjectContents)
+	})
+
+	t.Run("should refuse inline contents named like the file of another discipline", func(t *testing.T) {
+		// Arrange
+		file := filepath.Join(t.TempDir(), "math.csv")
+		project, err := planner.LoadProject(writeProject(t, "gostudy.yaml", `
+disciplines:
+  - name: Math
+    file: '`+file+`'
+    daily_limit: "01:00:00"
+  - name: '`+file+`'
+    daily_limit: "01:00:00"
+    contents:
+      - {subject: A, title: B, duration: "00:10:00"}
+`))
+		require.NoError(t, err)
+
+		// Act
+		_, err = project.DisciplineRows()
+
+		// Assert
+		assert.ErrorIs(t, err, planner.ErrDuplicateProjectContents)
+	})
+
+	t.Run("should refuse unknown extensions", func(t *testing.T) {
+		// Act
+		_, err := planner.LoadProject(writeProject(t, "gostudy.toml", ""))
+
+		// Assert
+		assert.ErrorIs(t, err, planner.ErrUnknownProjectFormat)
+	})
+}
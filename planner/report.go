@@ -0,0 +1,112 @@
+package planner
+
+import (
+	"time"
+
+	"github.com/kaiquegarcia/gostudy/v2/logging"
+)
+
+type DisciplineReport struct {
+	Discipline *Discipline
+	Workload   time.Duration
+	ReviewTime time.Duration
+	FinishDate time.Time
+	Unplayable []*Content
+	Completed  []*Content
+}
+
+type Report struct {
+	StartDate        time.Time
+	EndDate          time.Time
+	Disciplines      []*DisciplineReport
+	DeadlineMisses   []DeadlineMiss
+	Skipped          []SkippedDate
+	WeeklyShortfalls []WeeklyShortfall
+}
+
+func newReport(startDate time.Time, disciplines []*Discipline) *Report {
+	report := &Report{
+		StartDate:        startDate,
+		Disciplines:      make([]*DisciplineReport, len(disciplines)),
+		DeadlineMisses:   make([]DeadlineMiss, 0),
+		Skipped:          make([]SkippedDate, 0),
+		WeeklyShortfalls: make([]WeeklyShortfall, 0),
+	}
+	for index, discipline := range disciplines {
+		report.Disciplines[index] = &DisciplineReport{
+			Discipline: discipline,
+			Unplayable: make([]*Content, 0),
+			Completed:  make([]*Content, 0),
+		}
+	}
+
+	return report
+}
+
+func (r *Report) placed(disciplineIndex int, output Output, isReview bool) {
+	dr := r.Disciplines[disciplineIndex]
+	if isReview {
+		dr.ReviewTime += output.Content.Duration
+	}
+
+	end := output.Time.Add(output.Content.Duration)
+	if end.After(dr.FinishDate) {
+		dr.FinishDate = end
+	}
+}
+
+func (r *Report) HasProblems() bool {
+	if len(r.DeadlineMisses) > 0 || len(r.WeeklyShortfalls) > 0 {
+		return true
+	}
+
+	for _, dr := range r.Disciplines {
+		if len(dr.Unplayable) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *Report) Print(printer logging.Printer) {
+	printer.Printf("\nplan from %s to %s\n", r.StartDate.Format(LayoutDateOnly), r.EndDate.Format(LayoutDateOnly))
+	for _, dr := range r.Disciplines {
+		printer.Printf(
+			"- %s: %s of content + %s of reviews, finishing at %s\n",
+			dr.Discipline.Name,
+			dr.Workload,
+			dr.ReviewTime,
+			dr.FinishDate.Format(LayoutDateOnly),
+		)
+		if len(dr.Completed) > 0 {
+			printer.Printf("  * %d contents already done or skipped were left out\n", len(dr.Completed))
+		}
+
+		for _, content := range dr.Unplayable {
+			printer.Printf("  * '%s' (%s) can never fit on the plan\n", content.Title, content.Duration)
+		}
+	}
+
+	for _, skipped := range r.Skipped {
+		printer.Printf("- skipped %s: %s\n", skipped.Date.Format(LayoutDateOnly), skipped.Reason)
+	}
+
+	for _, miss := range r.DeadlineMisses {
+		printer.Printf(
+			"- %s is missing %s of study to finish before its deadline (%s)\n",
+			miss.Discipline.Name,
+			miss.MissingHours,
+			miss.Deadline.Format(LayoutDateOnly),
+		)
+	}
+
+	for _, shortfall := range r.WeeklyShortfalls {
+		printer.Printf(
+			"- %s is missing %s to reach its weekly minimum on the week of %s\n",
+			shortfall.Discipline.Name,
+			shortfall.MissingHours,
+			shortfall.Week.Format(LayoutDateOnly),
+		)
+	}
+}
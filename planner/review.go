@@ -0,0 +1,86 @@
+package planner
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	reviewListSeparator = ";"
+	reviewIDSeparator   = "@"
+)
+
+type ReviewDuration struct {
+	Fixed time.Duration
+	Ratio float64
+}
+
+func (rd ReviewDuration) For(content *Content) time.Duration {
+	if rd.Fixed > 0 {
+		return rd.Fixed
+	}
+
+	return time.Duration(float64(content.Duration) * rd.Ratio).Round(time.Second)
+}
+
+type review struct {
+	disciplineIndex int
+	content         *Content
+	due             time.Time
+}
+
+func newReviewContent(content *Content, duration time.Duration, number int, total int) *Content {
+	return &Content{
+		ID:           fmt.Sprintf("%s%sreview-%d", content.ID, reviewIDSeparator, number),
+		Subject:      content.Subject,
+		Title:        fmt.Sprintf("%s (review %d/%d)", content.Title, number, total),
+		Duration:     duration,
+		Reference:    content.Reference,
+		Extra:        content.Extra,
+		Attempts:     0,
+		positionalID: content.positionalID,
+	}
+}
+
+func parseReviewIntervals(value string) ([]int, error) {
+	intervals := make([]int, 0)
+	if value == "" {
+		return intervals, nil
+	}
+
+	for _, piece := range strings.Split(value, reviewListSeparator) {
+		days, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(piece), "+")))
+		if err != nil || days <= 0 {
+			return nil, ErrInvalidReviewIntervals
+		}
+
+		intervals = append(intervals, days)
+	}
+
+	return intervals, nil
+}
+
+func parseReviewDuration(value string, options ...InputOption) (ReviewDuration, error) {
+	// when not informed, the review takes as long as the content itself
+	if value == "" {
+		return ReviewDuration{Ratio: 1}, nil
+	}
+
+	if strings.HasSuffix(value, "%") {
+		percentage, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(value, "%")), 64)
+		if err != nil || percentage <= 0 {
+			return ReviewDuration{}, ErrInvalidReviewDuration
+		}
+
+		return ReviewDuration{Ratio: percentage / 100}, nil
+	}
+
+	duration, err := ParseDuration(value, options...)
+	if err != nil {
+		return ReviewDuration{}, err
+	}
+
+	return ReviewDuration{Fixed: duration}, nil
+}
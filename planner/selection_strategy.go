@@ -0,0 +1,146 @@
+package planner
+
+import (
+	"time"
+)
+
+const (
+	StrategyRoundRobin           = "round-robin"
+	StrategyWeighted             = "weighted"
+	StrategyLeastRecentScheduled = "least-recent"
+	StrategyMostRemainingWork    = "most-remaining"
+	StrategyDeadline             = "deadline"
+)
+
+type SelectionCandidate struct {
+	Index           int
+	Discipline      *Discipline
+	LastScheduledAt time.Time
+	RemainingWork   time.Duration
+	// Slack is how much hour grade will be left until the discipline's deadline
+	// after its remaining work, only meaningful when it has a deadline.
+	Slack time.Duration
+}
+
+// SelectionStrategy decides which discipline gets the next turn. The candidates
+// are the disciplines that didn't have a turn in the current interval yet, in
+// index order, and it must return the Index of one of them.
+type SelectionStrategy interface {
+	Select(current int, candidates []SelectionCandidate) int
+}
+
+// SelectionStrategyFactory builds a new strategy, as some of them keep state
+// between their selections that must not leak from a plan to another.
+type SelectionStrategyFactory func() SelectionStrategy
+
+func NewSelectionStrategy(name string) (SelectionStrategy, error) {
+	factory, err := NewSelectionStrategyFactory(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return factory(), nil
+}
+
+func NewSelectionStrategyFactory(name string) (SelectionStrategyFactory, error) {
+	switch name {
+	case "", StrategyRoundRobin:
+		return func() SelectionStrategy { return &roundRobinStrategy{} }, nil
+	case StrategyWeighted:
+		return func() SelectionStrategy { return &weightedStrategy{credits: map[int]int{}} }, nil
+	case StrategyLeastRecentScheduled:
+		return func() SelectionStrategy { return &leastRecentStrategy{} }, nil
+	case StrategyMostRemainingWork:
+		return func() SelectionStrategy { return &mostRemainingWorkStrategy{} }, nil
+	case StrategyDeadline:
+		return func() SelectionStrategy { return &deadlineStrategy{} }, nil
+	}
+
+	return nil, ErrUnknownSelectionStrategy
+}
+
+type roundRobinStrategy struct{}
+
+func (rr *roundRobinStrategy) Select(current int, candidates []SelectionCandidate) int {
+	// the first candidate from the current index onwards, wrapping around
+	for _, c := range candidates {
+		if c.Index >= current {
+			return c.Index
+		}
+	}
+
+	return candidates[0].Index
+}
+
+// weightedStrategy is a smooth weighted round-robin: every candidate earns its
+// weight in credits on each selection and the chosen one pays the sum of them.
+type weightedStrategy struct {
+	credits map[int]int
+}
+
+func (ws *weightedStrategy) Select(current int, candidates []SelectionCandidate) int {
+	total := 0
+	selected := candidates[0].Index
+	for _, c := range candidates {
+		ws.credits[c.Index] += c.Discipline.Weight
+		total += c.Discipline.Weight
+		if ws.credits[c.Index] > ws.credits[selected] {
+			selected = c.Index
+		}
+	}
+
+	ws.credits[selected] -= total
+	return selected
+}
+
+type leastRecentStrategy struct{}
+
+func (lr *leastRecentStrategy) Select(current int, candidates []SelectionCandidate) int {
+	selected := candidates[0]
+	for _, c := range candidates[1:] {
+		if c.LastScheduledAt.Before(selected.LastScheduledAt) {
+			selected = c
+		}
+	}
+
+	return selected.Index
+}
+
+type mostRemainingWorkStrategy struct{}
+
+func (mr *mostRemainingWorkStrategy) Select(current int, candidates []SelectionCandidate) int {
+	selected := candidates[0]
+	for _, c := range candidates[1:] {
+		if c.RemainingWork > selected.RemainingWork {
+			selected = c
+		}
+	}
+
+	return selected.Index
+}
+
+// deadlineStrategy prioritizes the disciplines with less slack until their
+// deadlines, falling back to round-robin for the ones without deadlines.
+type deadlineStrategy struct {
+	fallback roundRobinStrategy
+}
+
+func (ds *deadlineStrategy) Select(current int, candidates []SelectionCandidate) int {
+	var selected *SelectionCandidate
+	for index := range candidates {
+		c := &candidates[index]
+		if c.Discipline.Deadline.IsZero() || c.RemainingWork <= 0 {
+			continue
+		}
+
+		if selected == nil || c.Slack < selected.Slack {
+			selected = c
+		}
+	}
+
+	if selected == nil {
+		return ds.fallback.Select(current, candidates)
+	}
+
+	return selected.Index
+}
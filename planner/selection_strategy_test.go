@@ -0,0 +1,120 @@
+package planner_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaiquegarcia/gostudy/v2/planner"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SelectionStrategy(t *testing.T) {
+	math := &planner.Discipline{Name: "Math", Weight: 3}
+	english := &planner.Discipline{Name: "English", Weight: 1}
+	history := &planner.Discipline{Name: "History", Weight: 1}
+	now, _ := time.Parse(time.RFC3339, "2024-01-01T10:00:00Z")
+	candidates := []planner.SelectionCandidate{
+		{Index: 0, Discipline: math, LastScheduledAt: now, RemainingWork: time.Hour},
+		{Index: 1, Discipline: english, LastScheduledAt: now.Add(-time.Hour), RemainingWork: 3 * time.Hour},
+		{Index: 2, Discipline: history, LastScheduledAt: now.Add(time.Hour), RemainingWork: 2 * time.Hour},
+	}
+
+	t.Run("round-robin should keep the current discipline if it's a candidate", func(t *testing.T) {
+		// Arrange
+		strategy, _ := planner.NewSelectionStrategy(planner.StrategyRoundRobin)
+
+		// Act
+		result := strategy.Select(1, candidates)
+
+		// Assert
+		assert.Equal(t, 1, result, "result should be the current index")
+	})
+
+	t.Run("round-robin should wrap around to the first candidate", func(t *testing.T) {
+		// Arrange
+		strategy, _ := planner.NewSelectionStrategy(planner.StrategyRoundRobin)
+
+		// Act
+		result := strategy.Select(2, candidates[:2])
+
+		// Assert
+		assert.Equal(t, 0, result, "result should be the first candidate")
+	})
+
+	t.Run("weighted should give turns proportionally to the weights", func(t *testing.T) {
+		// Arrange
+		strategy, _ := planner.NewSelectionStrategy(planner.StrategyWeighted)
+		turns := map[int]int{}
+
+		// Act
+		for i := 0; i < 10; i++ {
+			turns[strategy.Select(0, candidates)]++
+		}
+
+		// Assert
+		assert.Equal(t, 6, turns[0], "math should have 6 turns")
+		assert.Equal(t, 2, turns[1], "english should have 2 turns")
+		assert.Equal(t, 2, turns[2], "history should have 2 turns")
+	})
+
+	t.Run("least-recent should select the discipline scheduled the longest ago", func(t *testing.T) {
+		// Arrange
+		strategy, _ := planner.NewSelectionStrategy(planner.StrategyLeastRecentScheduled)
+
+		// Act
+		result := strategy.Select(0, candidates)
+
+		// Assert
+		assert.Equal(t, 1, result, "result should be english")
+	})
+
+	t.Run("most-remaining should select the discipline with more work left", func(t *testing.T) {
+		// Arrange
+		strategy, _ := planner.NewSelectionStrategy(planner.StrategyMostRemainingWork)
+
+		// Act
+		result := strategy.Select(0, candidates)
+
+		// Assert
+		assert.Equal(t, 1, result, "result should be english")
+	})
+
+	t.Run("should not accept unknown strategies", func(t *testing.T) {
+		// Act
+		_, err := planner.NewSelectionStrategy("random")
+
+		// Assert
+		assert.ErrorIs(t, err, planner.ErrUnknownSelectionStrategy, "err should be ErrUnknownSelectionStrategy")
+	})
+
+	t.Run("the makers sharing a strategy factory should plan the same", func(t *testing.T) {
+		// Arrange
+		factory, err := planner.NewSelectionStrategyFactory(planner.StrategyWeighted)
+		if !assert.Nil(t, err, "err from NewSelectionStrategyFactory should be nil") {
+			t.FailNow()
+		}
+		options := []planner.MakerOption{planner.WithSelectionStrategyFactory(factory)}
+		mount := func() []string {
+			hg := testHourGrade(t, "14:00-17:00")
+			disciplines := testDisciplines(t, [][]string{
+				{"Name", "Filename", "Daily Limit", "Content Gap", "Subject Gap", "Weight"},
+				{"Math", "math.csv", "03:00:00", "00:00:00", "00:00:00", "2"},
+				{"History", "history.csv", "03:00:00", "00:00:00", "00:00:00", "1"},
+			}, map[string][][]string{
+				"math.csv":    {{"Logic", "Sets", "00:30:00"}, {"Logic", "Relations", "00:30:00"}},
+				"history.csv": {{"Ancient", "Egypt", "00:30:00"}, {"Ancient", "Rome", "00:30:00"}},
+			})
+
+			outputs, err := mountOutputs(t, hg, disciplines, options...)
+			assert.Nil(t, err, "err from Mount should be nil")
+			return outputs
+		}
+
+		// Act
+		first := mount()
+		second := mount()
+
+		// Assert
+		assert.Equal(t, first, second, "the second plan should not depend on the first one")
+	})
+}
@@ -0,0 +1,61 @@
+package planner
+
+import (
+	"strings"
+	"time"
+)
+
+const weekdayListSeparator = ";"
+
+var weekdayAliases = map[string][]time.Weekday{
+	"weekdays": {time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+	"weekends": {time.Saturday, time.Sunday},
+}
+
+// parseWeekdays accepts full or abbreviated english names, like "MONDAY;wed",
+// and the "weekdays" and "weekends" aliases.
+func parseWeekdays(value string) ([]time.Weekday, error) {
+	weekdays := make([]time.Weekday, 0)
+	if value == "" {
+		return weekdays, nil
+	}
+
+	for _, piece := range strings.Split(value, weekdayListSeparator) {
+		name := strings.ToLower(strings.TrimSpace(piece))
+		if alias, exists := weekdayAliases[name]; exists {
+			weekdays = append(weekdays, alias...)
+			continue
+		}
+
+		weekday, err := parseWeekday(name)
+		if err != nil {
+			return nil, err
+		}
+
+		weekdays = append(weekdays, weekday)
+	}
+
+	return weekdays, nil
+}
+
+func parseWeekday(name string) (time.Weekday, error) {
+	if len(name) >= 3 {
+		for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+			if strings.HasPrefix(strings.ToLower(weekday.String()), name) {
+				return weekday, nil
+			}
+		}
+	}
+
+	return 0, ErrInvalidWeekday
+}
+
+func hasWeekday(weekdays []time.Weekday, weekday time.Weekday) bool {
+	for _, wd := range weekdays {
+		if wd == weekday {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"github.com/kaiquegarcia/gostudy/v2/logging"
+	"github.com/kaiquegarcia/gostudy/v2/planner"
+	"github.com/kaiquegarcia/gostudy/v2/utils"
+)
+
+func loadProgress(logger logging.Logger, filename string, delimiter byte, options ...planner.InputOption) (*planner.Progress, error) {
+	logger.Debug("reading '%s'", filename)
+	records, err := utils.ReadCSV(filename, delimiter)
+	if err != nil {
+		logger.Error(err, "could not read '%s'", filename)
+		return nil, err
+	}
+
+	progress, err := planner.NewProgressFromRows(records, options...)
+	if err != nil {
+		logger.Error(err, "could not extract progress from table records")
+		return nil, err
+	}
+
+	logger.Debug("%d contents found on the progress", len(progress.Entries()))
+	return progress, nil
+}
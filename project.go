@@ -0,0 +1,79 @@
+package main
+
+import (
+	"github.com/kaiquegarcia/gostudy/v2/logging"
+	"github.com/kaiquegarcia/gostudy/v2/planner"
+	"github.com/kaiquegarcia/gostudy/v2/utils"
+)
+
+var projectFilenames = []string{"gostudy.yaml", "gostudy.yml", "gostudy.json"}
+
+// inputRecords are the rows of the hour grade and disciplines list, plus the
+// rows of the contents written inline on a project file, indexed by filename.
+type inputRecords struct {
+	HourGrade   [][]string
+	Disciplines [][]string
+	Contents    map[string][][]string
+}
+
+// loadRecords reads the project file, if informed or found, or the CSV files otherwise.
+func loadRecords(logger logging.Logger, reqFilenames utils.RequiredFilenames, projectFilename string, delimiter byte) (*inputRecords, error) {
+	if projectFilename == "" {
+		for _, filename := range projectFilenames {
+			if utils.FileExists(filename) {
+				projectFilename = filename
+				break
+			}
+		}
+	}
+
+	if projectFilename != "" {
+		return loadProjectRecords(logger, projectFilename)
+	}
+
+	logger.Debug("reading '%s'", reqFilenames.HourGrade)
+	hourGradeRecords, err := utils.ReadCSV(reqFilenames.HourGrade, delimiter)
+	if err != nil {
+		logger.Error(err, "could not read '%s'", reqFilenames.HourGrade)
+		return nil, err
+	}
+
+	logger.Debug("reading '%s'", reqFilenames.DisciplinesList)
+	disciplineRecords, err := utils.ReadCSV(reqFilenames.DisciplinesList, delimiter)
+	if err != nil {
+		logger.Error(err, "could not read '%s'", reqFilenames.DisciplinesList)
+		return nil, err
+	}
+
+	return &inputRecords{
+		HourGrade:   hourGradeRecords,
+		Disciplines: disciplineRecords,
+	}, nil
+}
+
+func loadProjectRecords(logger logging.Logger, filename string) (*inputRecords, error) {
+	logger.Debug("reading project '%s'", filename)
+	project, err := planner.LoadProject(filename)
+	if err != nil {
+		logger.Error(err, "could not read '%s'", filename)
+		return nil, err
+	}
+
+	hourGradeRecords, err := project.HourGradeRows()
+	if err != nil {
+		logger.Error(err, "could not extract hour grade from '%s'", filename)
+		return nil, err
+	}
+
+	disciplineRecords, err := project.DisciplineRows()
+	if err != nil {
+		logger.Error(err, "could not extract disciplines list from '%s'", filename)
+		return nil, err
+	}
+
+	return &inputRecords{
+		HourGrade:   hourGradeRecords,
+		Disciplines: disciplineRecords,
+		Contents:    project.ContentRows(),
+	}, nil
+}
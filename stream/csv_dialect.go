@@ -0,0 +1,55 @@
+package stream
+
+import "bytes"
+
+// AutoDelimiter detects the delimiter from the header of each file.
+const AutoDelimiter byte = 0
+
+var (
+	supportedDelimiters = []byte{',', ';', '\t', '|'}
+	utf8BOM             = []byte{0xEF, 0xBB, 0xBF}
+)
+
+// CheckDelimiter accepts the supported delimiters and AutoDelimiter.
+func CheckDelimiter(delimiter byte) error {
+	if delimiter != AutoDelimiter && bytes.IndexByte(supportedDelimiters, delimiter) < 0 {
+		return ErrUnsupportedDelimiter
+	}
+
+	return nil
+}
+
+// DetectDelimiter picks the supported delimiter found the most on the first
+// line of data, out of quotes. Without any of them, it's a comma.
+func DetectDelimiter(data []byte) byte {
+	counts := map[byte]int{}
+	inQuotes := false
+	for _, b := range StripBOM(data) {
+		if b == '"' {
+			inQuotes = !inQuotes
+			continue
+		}
+
+		if !inQuotes && (b == '\n' || b == '\r') {
+			break
+		}
+
+		if !inQuotes {
+			counts[b]++
+		}
+	}
+
+	detected := supportedDelimiters[0]
+	for _, delimiter := range supportedDelimiters[1:] {
+		if counts[delimiter] > counts[detected] {
+			detected = delimiter
+		}
+	}
+
+	return detected
+}
+
+// StripBOM removes the UTF-8 byte order mark saved by some spreadsheet apps.
+func StripBOM(data []byte) []byte {
+	return bytes.TrimPrefix(data, utf8BOM)
+}
@@ -0,0 +1,84 @@
+package stream_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/kaiquegarcia/gostudy/v2/stream"
+	"github.com/stretchr/testify/assert"
+)
+
+func readAll(t *testing.T, content string, options ...stream.CSVOption) [][]string {
+	file, err := os.CreateTemp(t.TempDir(), "*.csv")
+	if !assert.Nil(t, err, "err from CreateTemp should be nil") {
+		t.FailNow()
+	}
+	file.WriteString(content)
+
+	cds, err := stream.NewCSVDataStream(file, options...)
+	if !assert.Nil(t, err, "err from NewCSVDataStream should be nil") {
+		t.FailNow()
+	}
+	defer cds.Close()
+
+	rows := make([][]string, 0)
+	for {
+		row, err := cds.Read()
+		if err != nil {
+			assert.ErrorIs(t, err, stream.ErrEOF, "err should be EOF")
+			return rows
+		}
+
+		rows = append(rows, row)
+	}
+}
+
+func Test_CSVDataStream_Dialects(t *testing.T) {
+	t.Run("should detect the delimiter and skip the BOM and the CRLF", func(t *testing.T) {
+		// Act
+		rows := readAll(t, "\xEF\xBB\xBFAssunto;Título;\"Duração, hh:mm:ss\"\r\nLógica;\"Conjuntos; Relações\";00:10:00\r\n")
+
+		// Assert
+		assert.Equal(t, [][]string{
+			{"Assunto", "Título", "Duração, hh:mm:ss"},
+			{"Lógica", "Conjuntos; Relações", "00:10:00"},
+		}, rows)
+	})
+
+	t.Run("should detect the delimiter of headers with line breaks inside quotes", func(t *testing.T) {
+		// Act
+		rows := readAll(t, "\"Title\n(Chapter, Section)\";Subject;Duration\nSets;Logic;00:10:00\n")
+
+		// Assert
+		assert.Equal(t, [][]string{
+			{"Title\n(Chapter, Section)", "Subject", "Duration"},
+			{"Sets", "Logic", "00:10:00"},
+		}, rows)
+	})
+
+	t.Run("should read lines ended by CR alone", func(t *testing.T) {
+		// Act
+		rows := readAll(t, "A\tB\r\"1\r2\"\t3\r")
+
+		// Assert
+		assert.Equal(t, [][]string{{"A", "B"}, {"1\n2", "3"}}, rows)
+	})
+
+	t.Run("should use the delimiter informed instead of detecting it", func(t *testing.T) {
+		// Act
+		rows := readAll(t, "A;B,C\n1;2,3\n", stream.WithDelimiter(';'))
+
+		// Assert
+		assert.Equal(t, [][]string{{"A", "B,C"}, {"1", "2,3"}}, rows)
+	})
+
+	t.Run("should refuse unsupported delimiters", func(t *testing.T) {
+		// Act
+		_, err := stream.NewCSVDataStreamFromBytes([]byte("A#B\n"), stream.WithDelimiter('#'))
+
+		// Assert
+		assert.ErrorIs(t, err, stream.ErrUnsupportedDelimiter)
+		assert.ErrorIs(t, stream.CheckDelimiter('#'), stream.ErrUnsupportedDelimiter)
+		assert.Nil(t, stream.CheckDelimiter(stream.AutoDelimiter), "the auto delimiter should be accepted")
+	})
+}
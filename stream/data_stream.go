@@ -1,130 +1,379 @@
 package stream
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 	"strings"
+	"unicode"
 )
 
 const (
-	bufferSize   = 64
-	csvSeparator = ","
+	bufferSize = 64
+	// the header is read at once to detect the delimiter, up to this length
+	headerSizeLimit = 64 * 1024
 )
 
 var (
-	ErrCannotUnread = fmt.Errorf("it's not possible to unread something not readed")
-	ErrEOF          = fmt.Errorf("reached end of file")
+	ErrCannotUnread         = fmt.Errorf("it's not possible to unread something not readed")
+	ErrEOF                  = fmt.Errorf("reached end of file")
+	ErrUnclosedQuote        = fmt.Errorf("the quoted field must be closed by another quote")
+	ErrUnexpectedQuote      = fmt.Errorf("the quoted field must be followed by the separator or a line break")
+	ErrUnsupportedDelimiter = fmt.Errorf("the delimiter must be ',', ';', tab or '|'")
+	ErrInvalidRow           = fmt.Errorf("the row must not be negative")
+	ErrInvalidJSONLine      = fmt.Errorf("each line must have a single JSON object, with texts, numbers, booleans or lists of them")
 )
 
+// DataStream reads the rows of a table one at a time. The rows are counted
+// from 0, the header, and every row read can be unread back to the first.
+// The table ends on the end of the data or on a blank row, where Read keeps
+// returning ErrEOF without moving.
 type DataStream interface {
 	Read() ([]string, error)
+	// Unread steps back one row, as many times as rows were read
 	Unread() error
+	// Mark saves the current position, to come back to it with Reset
+	Mark() Mark
+	Reset(mark Mark) error
+	// Seek moves to the row, so it's the next one returned by Read
+	Seek(row int) error
+	// Peek returns up to n next rows without consuming them, fewer at the end of the stream
+	Peek(n int) ([][]string, error)
 	Close() error
 }
 
-func NewCSVDataStream(file *os.File) (DataStream, error) {
+// Mark is a position of a DataStream, the index of the next row to read.
+type Mark struct {
+	row int
+}
+
+func (m Mark) Row() int {
+	return m.row
+}
+
+// peek reads ahead and resets the stream to where it was, for every DataStream.
+func peek(ds DataStream, n int) ([][]string, error) {
+	mark := ds.Mark()
+	rows := make([][]string, 0, n)
+	for len(rows) < n {
+		row, err := ds.Read()
+		if err == ErrEOF {
+			break
+		}
+
+		if err != nil {
+			ds.Reset(mark)
+			return nil, err
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, ds.Reset(mark)
+}
+
+// seekForward reads until the row, returning ErrEOF if the stream ends before it.
+func seekForward(ds DataStream, from int, row int) error {
+	for ; from < row; from++ {
+		_, err := ds.Read()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type CSVOption func(cds *csvDataStream)
+
+// WithDelimiter reads the file with the delimiter instead of detecting it from the
+// header. AutoDelimiter keeps the detection.
+func WithDelimiter(delimiter byte) CSVOption {
+	return func(cds *csvDataStream) {
+		cds.delimiter = delimiter
+	}
+}
+
+func NewCSVDataStream(file *os.File, options ...CSVOption) (DataStream, error) {
 	finfo, err := file.Stat()
 	if err != nil {
 		return nil, ErrEOF
 	}
 
-	return &csvDataStream{
-		file:     file,
-		previous: 0,
-		readed:   0,
-		next:     0,
-		size:     finfo.Size(),
-		ended:    false,
-	}, nil
+	return NewCSVDataStreamFromReaderAt(file, finfo.Size(), options...)
 }
 
-type csvDataStream struct {
-	file     *os.File
-	previous int64
-	readed   int64
-	next     int64
-	size     int64
-	buffer   []byte
-	ended    bool
+// NewCSVDataStreamFromBytes reads CSV data already in memory, like embedded files or request bodies.
+func NewCSVDataStreamFromBytes(data []byte, options ...CSVOption) (DataStream, error) {
+	return NewCSVDataStreamFromReaderAt(bytes.NewReader(data), int64(len(data)), options...)
 }
 
-func (cds *csvDataStream) Read() ([]string, error) {
-	line, err := cds.readLine()
+// NewCSVDataStreamFromReaderAt reads the first size bytes of reader. Closing
+// the stream closes the reader too, if it's an io.Closer.
+func NewCSVDataStreamFromReaderAt(reader io.ReaderAt, size int64, options ...CSVOption) (DataStream, error) {
+	cds := &csvDataStream{
+		reader:    reader,
+		history:   make([]int64, 0),
+		next:      0,
+		size:      size,
+		delimiter: AutoDelimiter,
+	}
+	for _, option := range options {
+		option(cds)
+	}
+
+	err := CheckDelimiter(cds.delimiter)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := cds.header()
 	if err != nil {
 		return nil, err
 	}
 
-	rawColumns := strings.Split(line, csvSeparator)
-	columns := make([]string, len(rawColumns))
-	for i, c := range rawColumns {
-		columns[i] = strings.TrimSpace(c)
+	// the records start after the BOM, if any
+	cds.next = int64(len(header) - len(StripBOM(header)))
+	if cds.delimiter == AutoDelimiter {
+		cds.delimiter = DetectDelimiter(header)
+	}
+
+	return cds, nil
+}
+
+// csvDataStream reads one record at a time, following RFC 4180, keeping only
+// a small buffer of the data in memory. The records are found by their offsets,
+// so the last one can be read again after Unread.
+type csvDataStream struct {
+	reader io.ReaderAt
+	// offsets of the rows already read, to unread them
+	history []int64
+	next    int64
+	size    int64
+	buffer  []byte
+	// offset of the first byte of the buffer
+	bufferAt  int64
+	delimiter byte
+}
+
+func (cds *csvDataStream) Read() ([]string, error) {
+	if cds.next >= cds.size {
+		return nil, ErrEOF
+	}
+
+	columns, next, err := cds.readRecord(cds.next)
+	if err != nil {
+		return nil, err
 	}
 
+	// a blank line ends the table, so the stream stays on it like at the end of the file
 	if len(columns) == 1 && columns[0] == "" {
 		return nil, ErrEOF
 	}
 
+	cds.history = append(cds.history, cds.next)
+	cds.next = next
 	return columns, nil
 }
 
-func (cds *csvDataStream) readLine() (string, error) {
-	if cds.ended {
-		return "", ErrEOF
+// header returns the bytes of the first record, BOM and line break included.
+// Its quoted fields may have line breaks too, so it's found like any record.
+func (cds *csvDataStream) header() ([]byte, error) {
+	_, end, err := cds.readRecord(0)
+	if err != nil && err != ErrUnclosedQuote && err != ErrUnexpectedQuote {
+		return nil, err
 	}
 
-	phrase := ""
-	cds.readed = 0
-	for {
-		for index, b := range cds.buffer {
-			if b == '\n' && phrase != "" {
-				cds.previous = cds.next
-				cds.next += cds.readed
-				cds.buffer = cds.buffer[index:]
-				if cds.previous == cds.next {
-					cds.ended = true
-				}
+	// the malformed quotes are reported by Read, so the header stops at them
+
+	header := make([]byte, 0)
+	for offset := int64(0); offset < end && offset < headerSizeLimit; offset++ {
+		b, err := cds.byteAt(offset)
+		if err != nil {
+			return nil, err
+		}
+
+		header = append(header, b)
+	}
 
-				return phrase, nil
+	return header, nil
+}
+
+// readRecord parses the record starting at offset, returning the offset of the
+// next one. Quoted fields may have separators, line breaks and doubled quotes,
+// the unquoted ones are trimmed. The line breaks may be \n, \r\n or \r, and
+// they're kept as \n inside quotes.
+func (cds *csvDataStream) readRecord(offset int64) ([]string, int64, error) {
+	var (
+		columns  = make([]string, 0)
+		field    = make([]byte, 0)
+		quoted   bool
+		inQuotes bool
+	)
+	endField := func() {
+		if quoted {
+			columns = append(columns, string(field))
+		} else {
+			columns = append(columns, trimColumn(string(field)))
+		}
+
+		field = field[:0]
+		quoted = false
+	}
+
+	for ; ; offset++ {
+		b, err := cds.byteAt(offset)
+		if err == io.EOF {
+			if inQuotes {
+				return nil, offset, ErrUnclosedQuote
 			}
 
-			phrase += string(b)
-			cds.readed++
+			endField()
+			return columns, offset, nil
 		}
 
-		if cds.next+cds.readed >= cds.size {
-			cds.ended = true
-			return phrase, nil
+		if err != nil {
+			return nil, offset, err
+		}
+
+		switch {
+		case inQuotes && b == '"':
+			following, err := cds.byteAt(offset + 1)
+			if err == nil && following == '"' {
+				field = append(field, '"')
+				offset++
+				continue
+			}
+
+			inQuotes = false
+		case inQuotes && b == '\r':
+			field = append(field, '\n')
+			if following, err := cds.byteAt(offset + 1); err == nil && following == '\n' {
+				offset++
+			}
+		case inQuotes:
+			field = append(field, b)
+		case cds.isDelimiter(b):
+			endField()
+		case b == '\n':
+			endField()
+			return columns, offset + 1, nil
+		case b == '\r':
+			endField()
+			if following, err := cds.byteAt(offset + 1); err == nil && following == '\n' {
+				offset++
+			}
+
+			return columns, offset + 1, nil
+		case quoted:
+			// only spaces may follow the closing quote
+			if !unicode.IsSpace(rune(b)) {
+				return nil, offset, ErrUnexpectedQuote
+			}
+		case b == '"' && strings.TrimSpace(string(field)) == "":
+			field = field[:0]
+			quoted = true
+			inQuotes = true
+		default:
+			field = append(field, b)
 		}
+	}
+}
+
+// isDelimiter accepts every supported delimiter while the header is read to detect it.
+func (cds *csvDataStream) isDelimiter(b byte) bool {
+	if cds.delimiter == AutoDelimiter {
+		return bytes.IndexByte(supportedDelimiters, b) >= 0
+	}
 
+	return b == cds.delimiter
+}
+
+func (cds *csvDataStream) byteAt(offset int64) (byte, error) {
+	if offset >= cds.size {
+		return 0, io.EOF
+	}
+
+	if offset < cds.bufferAt || offset >= cds.bufferAt+int64(len(cds.buffer)) {
 		buffer := make([]byte, bufferSize)
-		readed, err := cds.file.ReadAt(buffer, cds.next+cds.readed)
-		if errors.Is(io.EOF, err) && readed == 0 {
-			cds.ended = true
-			return phrase, nil
+		readed, err := cds.reader.ReadAt(buffer, offset)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return 0, err
 		}
 
-		if err != nil && !errors.Is(io.EOF, err) {
-			return "", err
+		if readed == 0 {
+			return 0, io.EOF
 		}
 
 		cds.buffer = buffer[:readed]
+		cds.bufferAt = offset
 	}
+
+	return cds.buffer[offset-cds.bufferAt], nil
 }
 
 func (cds *csvDataStream) Unread() error {
-	if cds.previous >= cds.next {
+	if len(cds.history) == 0 {
 		return ErrCannotUnread
 	}
 
-	cds.next = cds.previous
-	cds.buffer = make([]byte, 0)
-	cds.ended = false
+	cds.next = cds.history[len(cds.history)-1]
+	cds.history = cds.history[:len(cds.history)-1]
 	return nil
 }
 
+func (cds *csvDataStream) Mark() Mark {
+	return Mark{row: len(cds.history)}
+}
+
+func (cds *csvDataStream) Reset(mark Mark) error {
+	return cds.Seek(mark.row)
+}
+
+func (cds *csvDataStream) Seek(row int) error {
+	if row < 0 {
+		return ErrInvalidRow
+	}
+
+	current := len(cds.history)
+	if row > current {
+		err := seekForward(cds, current, row)
+		if err != nil {
+			cds.Seek(current)
+			return err
+		}
+
+		return nil
+	}
+
+	if row < current {
+		cds.next = cds.history[row]
+		cds.history = cds.history[:row]
+	}
+
+	return nil
+}
+
+func (cds *csvDataStream) Peek(n int) ([][]string, error) {
+	return peek(cds, n)
+}
+
 func (cds *csvDataStream) Close() error {
 	cds.buffer = make([]byte, 0)
-	return cds.file.Close()
+	return closeReader(cds.reader)
+}
+
+// closeReader closes the readers that can be closed, like files.
+func closeReader(reader io.ReaderAt) error {
+	if closer, ok := reader.(io.Closer); ok {
+		return closer.Close()
+	}
+
+	return nil
+}
+
+func trimColumn(column string) string {
+	return strings.TrimSpace(column)
 }
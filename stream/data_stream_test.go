@@ -341,3 +341,237 @@ func Test_CSVDataStream_BiggerFile(t *testing.T) {
 		assert.Len(t, row, 0, "row should have 0 columns")
 	})
 }
+
+func Test_CSVDataStream_QuotedFields(t *testing.T) {
+	file, _ := os.Open("file_with_quotes_test.csv")
+	defer file.Close()
+
+	t.Run("should keep separators, quotes and line breaks inside quoted fields", func(t *testing.T) {
+		// Arrange
+		cds, err := stream.NewCSVDataStream(file)
+		if !assert.Nil(t, err, "err from NewCSVDataStream should be nil") {
+			t.FailNow()
+		}
+
+		// Act
+		rows := make([][]string, 0)
+		for {
+			row, err := cds.Read()
+			if err != nil {
+				assert.ErrorIs(t, err, stream.ErrEOF, "err should be EOF")
+				break
+			}
+
+			rows = append(rows, row)
+		}
+
+		// Assert
+		if !assert.Len(t, rows, 4, "should have 4 rows") {
+			t.FailNow()
+		}
+		assert.Equal(t, []string{"Subject (whatever you want, repeatable)", "Title", "Duration"}, rows[0])
+		assert.Equal(t, []string{"1. Sets", "Sets, Relations and Functions", "00:10:00"}, rows[1])
+		assert.Equal(t, []string{"2. Quotes", `The "best" title`, "00:20:00"}, rows[2])
+		assert.Equal(t, []string{"3. Lines", "First line\nsecond line", "00:30:00"}, rows[3])
+	})
+
+	t.Run("should return the multi-line row again after Unread", func(t *testing.T) {
+		// Arrange
+		cds, err := stream.NewCSVDataStream(file)
+		if !assert.Nil(t, err, "err from NewCSVDataStream should be nil") {
+			t.FailNow()
+		}
+
+		for index := 0; index < 4; index++ {
+			cds.Read()
+		}
+
+		// Act
+		err = cds.Unread()
+		if !assert.Nil(t, err, "err from Unread should be nil") {
+			t.FailNow()
+		}
+
+		row, err := cds.Read()
+
+		// Assert
+		assert.Nil(t, err, "err from Read-after-Unread should be nil")
+		assert.Equal(t, []string{"3. Lines", "First line\nsecond line", "00:30:00"}, row)
+	})
+
+	t.Run("should fail on malformed quotes", func(t *testing.T) {
+		for content, expected := range map[string]error{
+			"A,\"B\nC,D\n": stream.ErrUnclosedQuote,
+			"A,\"B\"C,D\n": stream.ErrUnexpectedQuote,
+		} {
+			// Arrange
+			malformed, err := os.CreateTemp(t.TempDir(), "*.csv")
+			if !assert.Nil(t, err, "err from CreateTemp should be nil") {
+				t.FailNow()
+			}
+			malformed.WriteString(content)
+			cds, _ := stream.NewCSVDataStream(malformed)
+
+			// Act
+			_, err = cds.Read()
+
+			// Assert
+			assert.ErrorIs(t, err, expected)
+			cds.Close()
+		}
+	})
+}
+
+func Test_DataStream_Positions(t *testing.T) {
+	file, _ := os.Open("file_for_test.csv")
+	defer file.Close()
+
+	streams := map[string]func() stream.DataStream{
+		"csv": func() stream.DataStream {
+			cds, _ := stream.NewCSVDataStream(file)
+			return cds
+		},
+		"memory": func() stream.DataStream {
+			return stream.NewMemoryDataStream([][]string{
+				{"Header 1", "Header 2", "Header 3", "Header 4"},
+				{"A1", "B1", "C1", "D1"},
+				{"A2", "B2", "C2", "D2"},
+				{"A3", "B3", "C3", "D3"},
+			})
+		},
+	}
+
+	for name, newStream := range streams {
+		t.Run(name+" should unread many rows", func(t *testing.T) {
+			// Arrange
+			ds := newStream()
+			for index := 0; index < 3; index++ {
+				ds.Read()
+			}
+
+			// Act
+			firstErr := ds.Unread()
+			secondErr := ds.Unread()
+			row, err := ds.Read()
+
+			// Assert
+			assert.Nil(t, firstErr, "err from first Unread should be nil")
+			assert.Nil(t, secondErr, "err from second Unread should be nil")
+			assert.Nil(t, err, "err from Read should be nil")
+			assert.Equal(t, "A1", row[0], "row[0] should be 'A1'")
+		})
+
+		t.Run(name+" should not unread before the first row", func(t *testing.T) {
+			// Arrange
+			ds := newStream()
+			ds.Read()
+			ds.Unread()
+
+			// Act
+			err := ds.Unread()
+
+			// Assert
+			assert.ErrorIs(t, err, stream.ErrCannotUnread)
+		})
+
+		t.Run(name+" should come back to the mark", func(t *testing.T) {
+			// Arrange
+			ds := newStream()
+			ds.Read()
+			mark := ds.Mark()
+			ds.Read()
+			ds.Read()
+
+			// Act
+			err := ds.Reset(mark)
+			row, readErr := ds.Read()
+
+			// Assert
+			assert.Nil(t, err, "err from Reset should be nil")
+			assert.Nil(t, readErr, "err from Read should be nil")
+			assert.Equal(t, 1, mark.Row(), "mark should be on row 1")
+			assert.Equal(t, "A1", row[0], "row[0] should be 'A1'")
+		})
+
+		t.Run(name+" should seek forward and backward", func(t *testing.T) {
+			// Arrange
+			ds := newStream()
+
+			// Act
+			forwardErr := ds.Seek(3)
+			forward, _ := ds.Read()
+			backwardErr := ds.Seek(2)
+			backward, _ := ds.Read()
+			beyondErr := ds.Seek(10)
+			afterBeyond, _ := ds.Read()
+
+			// Assert
+			assert.Nil(t, forwardErr, "err from forward Seek should be nil")
+			assert.Nil(t, backwardErr, "err from backward Seek should be nil")
+			assert.ErrorIs(t, beyondErr, stream.ErrEOF, "seeking beyond the end should be EOF")
+			assert.Equal(t, "A3", forward[0], "row 3 should start with 'A3'")
+			assert.Equal(t, "A2", backward[0], "row 2 should start with 'A2'")
+			assert.Equal(t, "A3", afterBeyond[0], "a failed Seek should not move the stream")
+			assert.ErrorIs(t, ds.Seek(-1), stream.ErrInvalidRow)
+		})
+
+		t.Run(name+" should peek without consuming", func(t *testing.T) {
+			// Arrange
+			ds := newStream()
+			ds.Read()
+
+			// Act
+			rows, err := ds.Peek(5)
+			next, _ := ds.Read()
+
+			// Assert
+			assert.Nil(t, err, "err from Peek should be nil")
+			assert.Len(t, rows, 3, "should peek only the 3 rows left")
+			assert.Equal(t, "A1", next[0], "the next row should still be 'A1'")
+		})
+	}
+}
+
+func Test_DataStream_EOF(t *testing.T) {
+	streams := map[string]func() (stream.DataStream, error){
+		"csv": func() (stream.DataStream, error) {
+			return stream.NewCSVDataStreamFromBytes([]byte("a,b\n1,2\n\n3,4\n"))
+		},
+		"memory": func() (stream.DataStream, error) {
+			return stream.NewMemoryDataStream([][]string{{"a", "b"}, {"1", "2"}, {}, {"3", "4"}}), nil
+		},
+		"json lines": func() (stream.DataStream, error) {
+			return stream.NewJSONLinesDataStreamFromBytes([]byte(`{"a": 1, "b": 2}` + "\n"))
+		},
+	}
+
+	for name, newStream := range streams {
+		t.Run(name+" should stay at the end once reached", func(t *testing.T) {
+			// Arrange
+			ds, err := newStream()
+			if !assert.Nil(t, err, "err from the constructor should be nil") {
+				t.FailNow()
+			}
+			ds.Read()
+			ds.Read()
+
+			// Act
+			_, firstErr := ds.Read()
+			_, secondErr := ds.Read()
+			mark := ds.Mark()
+			peeked, peekErr := ds.Peek(1)
+			unreadErr := ds.Unread()
+			row, readErr := ds.Read()
+
+			// Assert
+			assert.ErrorIs(t, firstErr, stream.ErrEOF, "the first Read at the end should be EOF")
+			assert.ErrorIs(t, secondErr, stream.ErrEOF, "the next Read should still be EOF")
+			assert.Equal(t, 2, mark.Row(), "the end should not count as a row")
+			assert.Nil(t, peekErr, "err from Peek should be nil")
+			assert.Empty(t, peeked, "Peek should not read past the end")
+			assert.Nil(t, unreadErr, "err from Unread should be nil")
+			assert.Nil(t, readErr, "err from Read should be nil")
+			assert.Equal(t, []string{"1", "2"}, row, "Unread should go back to the last row")
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package stream
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const jsonListSeparator = ";"
+
+// jsonLine is where a non-blank line is on the reader.
+type jsonLine struct {
+	offset int64
+	length int
+}
+
+// jsonLinesDataStream serves one JSON object per line as rows. The header, row
+// 0, has every key found on the objects, in order of appearance, and each row
+// has the values of its object on the positions of their keys.
+type jsonLinesDataStream struct {
+	reader  io.ReaderAt
+	header  []string
+	indexes map[string]int
+	lines   []jsonLine
+	next    int
+}
+
+func NewJSONLinesDataStream(file *os.File) (DataStream, error) {
+	finfo, err := file.Stat()
+	if err != nil {
+		return nil, ErrEOF
+	}
+
+	return NewJSONLinesDataStreamFromReaderAt(file, finfo.Size())
+}
+
+func NewJSONLinesDataStreamFromBytes(data []byte) (DataStream, error) {
+	return NewJSONLinesDataStreamFromReaderAt(bytes.NewReader(data), int64(len(data)))
+}
+
+// NewJSONLinesDataStreamFromReaderAt reads the first size bytes of reader once,
+// to find the lines and the keys, keeping only their positions in memory.
+// Closing the stream closes the reader too, if it's an io.Closer.
+func NewJSONLinesDataStreamFromReaderAt(reader io.ReaderAt, size int64) (DataStream, error) {
+	jds := &jsonLinesDataStream{
+		reader:  reader,
+		header:  make([]string, 0),
+		indexes: map[string]int{},
+		lines:   make([]jsonLine, 0),
+	}
+
+	br := bufio.NewReader(io.NewSectionReader(reader, 0, size))
+	var offset int64 = 0
+	for number := 1; ; number++ {
+		line, err := br.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+
+		content := line
+		if number == 1 {
+			content = StripBOM(content)
+		}
+
+		if trimmed := bytes.TrimSpace(content); len(trimmed) > 0 {
+			keys, _, parseErr := parseJSONLine(trimmed)
+			if parseErr != nil {
+				return nil, fmt.Errorf("%w on line %d", parseErr, number)
+			}
+
+			for _, key := range keys {
+				if _, exists := jds.indexes[key]; !exists {
+					jds.indexes[key] = len(jds.header)
+					jds.header = append(jds.header, key)
+				}
+			}
+
+			jds.lines = append(jds.lines, jsonLine{
+				offset: offset + int64(len(line)-len(content)),
+				length: len(content),
+			})
+		}
+
+		offset += int64(len(line))
+		if err == io.EOF {
+			return jds, nil
+		}
+	}
+}
+
+func (jds *jsonLinesDataStream) Read() ([]string, error) {
+	if jds.next == 0 {
+		jds.next++
+		return append(make([]string, 0, len(jds.header)), jds.header...), nil
+	}
+
+	if jds.next > len(jds.lines) {
+		return nil, ErrEOF
+	}
+
+	line := jds.lines[jds.next-1]
+	data := make([]byte, line.length)
+	_, err := jds.reader.ReadAt(data, line.offset)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	keys, values, err := parseJSONLine(bytes.TrimSpace(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w on row %d", err, jds.next)
+	}
+
+	columns := make([]string, len(jds.header))
+	for index, key := range keys {
+		columns[jds.indexes[key]] = trimColumn(values[index])
+	}
+
+	jds.next++
+	return columns, nil
+}
+
+func (jds *jsonLinesDataStream) Unread() error {
+	if jds.next == 0 {
+		return ErrCannotUnread
+	}
+
+	jds.next--
+	return nil
+}
+
+func (jds *jsonLinesDataStream) Mark() Mark {
+	return Mark{row: jds.next}
+}
+
+func (jds *jsonLinesDataStream) Reset(mark Mark) error {
+	return jds.Seek(mark.row)
+}
+
+func (jds *jsonLinesDataStream) Seek(row int) error {
+	if row < 0 {
+		return ErrInvalidRow
+	}
+
+	// the header and every line are rows
+	if row > len(jds.lines)+1 {
+		return ErrEOF
+	}
+
+	jds.next = row
+	return nil
+}
+
+func (jds *jsonLinesDataStream) Peek(n int) ([][]string, error) {
+	return peek(jds, n)
+}
+
+func (jds *jsonLinesDataStream) Close() error {
+	return closeReader(jds.reader)
+}
+
+// parseJSONLine reads the keys and values of an object in order. The lists are
+// joined by ';', null is empty and the other values are kept as written.
+func parseJSONLine(line []byte) ([]string, []string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(line))
+	decoder.UseNumber()
+	token, err := decoder.Token()
+	if err != nil || token != json.Delim('{') {
+		return nil, nil, ErrInvalidJSONLine
+	}
+
+	keys := make([]string, 0)
+	values := make([]string, 0)
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, nil, ErrInvalidJSONLine
+		}
+
+		var raw json.RawMessage
+		err = decoder.Decode(&raw)
+		if err != nil {
+			return nil, nil, ErrInvalidJSONLine
+		}
+
+		value, err := jsonValue(raw)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		keys = append(keys, token.(string))
+		values = append(values, value)
+	}
+
+	if _, err := decoder.Token(); err != nil {
+		return nil, nil, ErrInvalidJSONLine
+	}
+
+	if decoder.More() {
+		// more than one object on the line
+		return nil, nil, ErrInvalidJSONLine
+	}
+
+	return keys, values, nil
+}
+
+func jsonValue(raw json.RawMessage) (string, error) {
+	switch raw[0] {
+	case '"':
+		var value string
+		err := json.Unmarshal(raw, &value)
+		return value, err
+	case '[':
+		var items []json.RawMessage
+		err := json.Unmarshal(raw, &items)
+		if err != nil {
+			return "", err
+		}
+
+		values := make([]string, len(items))
+		for index, item := range items {
+			if item[0] == '[' || item[0] == '{' {
+				return "", ErrInvalidJSONLine
+			}
+
+			values[index], err = jsonValue(item)
+			if err != nil {
+				return "", err
+			}
+		}
+
+		return strings.Join(values, jsonListSeparator), nil
+	case '{':
+		return "", ErrInvalidJSONLine
+	}
+
+	if string(raw) == "null" {
+		return "", nil
+	}
+
+	return string(raw), nil
+}
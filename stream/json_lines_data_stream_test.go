@@ -0,0 +1,84 @@
+package stream_test
+
+import (
+	"testing"
+
+	"github.com/kaiquegarcia/gostudy/v2/stream"
+	"github.com/stretchr/testify/assert"
+)
+
+const jsonLines = `{"subject": "Logic", "title": "Sets", "duration": "00:30:00", "id": "math-1"}
+
+{"subject": "Logic", "title": "Induction", "duration": "00:45:00", "prerequisites": ["math-1", "math-0"], "weight": 2, "draft": null}
+`
+
+func Test_JSONLinesDataStream(t *testing.T) {
+	t.Run("should read the keys as header and the objects as rows", func(t *testing.T) {
+		// Arrange
+		jds, err := stream.NewJSONLinesDataStreamFromBytes([]byte(jsonLines))
+		if !assert.Nil(t, err, "err from NewJSONLinesDataStreamFromBytes should be nil") {
+			t.FailNow()
+		}
+
+		// Act
+		header, _ := jds.Read()
+		first, _ := jds.Read()
+		second, _ := jds.Read()
+		_, eofErr := jds.Read()
+
+		// Assert
+		assert.Equal(t, []string{"subject", "title", "duration", "id", "prerequisites", "weight", "draft"}, header)
+		assert.Equal(t, []string{"Logic", "Sets", "00:30:00", "math-1", "", "", ""}, first)
+		assert.Equal(t, []string{"Logic", "Induction", "00:45:00", "", "math-1;math-0", "2", ""}, second)
+		assert.ErrorIs(t, eofErr, stream.ErrEOF)
+	})
+
+	t.Run("should move between the rows", func(t *testing.T) {
+		// Arrange
+		jds, err := stream.NewJSONLinesDataStreamFromBytes([]byte(jsonLines))
+		if !assert.Nil(t, err, "err from NewJSONLinesDataStreamFromBytes should be nil") {
+			t.FailNow()
+		}
+
+		// Act
+		seekErr := jds.Seek(2)
+		row, _ := jds.Read()
+		unreadErr := jds.Unread()
+		peeked, peekErr := jds.Peek(3)
+
+		// Assert
+		assert.Nil(t, seekErr, "err from Seek should be nil")
+		assert.Nil(t, unreadErr, "err from Unread should be nil")
+		assert.Nil(t, peekErr, "err from Peek should be nil")
+		assert.Equal(t, "Induction", row[1])
+		assert.Len(t, peeked, 1, "should peek only the last row")
+		assert.ErrorIs(t, jds.Seek(4), stream.ErrEOF)
+	})
+
+	t.Run("should refuse invalid lines telling where they are", func(t *testing.T) {
+		for _, content := range []string{"{\"title\": \"Sets\"}\nnot json\n", "{\"title\": {\"nested\": true}}\n", "[1, 2]\n"} {
+			// Act
+			_, err := stream.NewJSONLinesDataStreamFromBytes([]byte(content))
+
+			// Assert
+			assert.ErrorIs(t, err, stream.ErrInvalidJSONLine, "%q should be invalid", content)
+		}
+	})
+}
+
+func Test_CSVDataStream_FromBytes(t *testing.T) {
+	// Arrange
+	cds, err := stream.NewCSVDataStreamFromBytes([]byte("A,B\n1,2\n"))
+	if !assert.Nil(t, err, "err from NewCSVDataStreamFromBytes should be nil") {
+		t.FailNow()
+	}
+
+	// Act
+	rows, peekErr := cds.Peek(3)
+	closeErr := cds.Close()
+
+	// Assert
+	assert.Nil(t, peekErr, "err from Peek should be nil")
+	assert.Nil(t, closeErr, "err from Close should be nil")
+	assert.Equal(t, [][]string{{"A", "B"}, {"1", "2"}}, rows)
+}
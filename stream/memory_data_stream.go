@@ -0,0 +1,76 @@
+package stream
+
+// NewMemoryDataStream serves rows already in memory, like the contents written
+// inline on a project file. The rows are trimmed the same way of the CSV stream.
+func NewMemoryDataStream(rows [][]string) DataStream {
+	return &memoryDataStream{rows: rows}
+}
+
+type memoryDataStream struct {
+	rows [][]string
+	next int
+}
+
+func (mds *memoryDataStream) Read() ([]string, error) {
+	if mds.next >= len(mds.rows) {
+		return nil, ErrEOF
+	}
+
+	row := mds.rows[mds.next]
+	if len(row) == 0 {
+		return nil, ErrEOF
+	}
+
+	mds.next++
+	columns := make([]string, len(row))
+	for i, c := range row {
+		columns[i] = trimColumn(c)
+	}
+
+	return columns, nil
+}
+
+func (mds *memoryDataStream) Unread() error {
+	if mds.next == 0 {
+		return ErrCannotUnread
+	}
+
+	mds.next--
+	return nil
+}
+
+func (mds *memoryDataStream) Mark() Mark {
+	return Mark{row: mds.next}
+}
+
+func (mds *memoryDataStream) Reset(mark Mark) error {
+	return mds.Seek(mark.row)
+}
+
+func (mds *memoryDataStream) Seek(row int) error {
+	if row < 0 {
+		return ErrInvalidRow
+	}
+
+	if row > mds.next {
+		current := mds.next
+		err := seekForward(mds, current, row)
+		if err != nil {
+			mds.next = current
+			return err
+		}
+
+		return nil
+	}
+
+	mds.next = row
+	return nil
+}
+
+func (mds *memoryDataStream) Peek(n int) ([][]string, error) {
+	return peek(mds, n)
+}
+
+func (mds *memoryDataStream) Close() error {
+	return nil
+}
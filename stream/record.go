@@ -0,0 +1,120 @@
+package stream
+
+import "strings"
+
+// Header maps the column names to their positions, so the columns can be
+// found regardless of where the user placed them.
+type Header struct {
+	names   []string
+	indexes map[string]int
+}
+
+func NewHeader(columns []string) *Header {
+	h := &Header{
+		names:   make([]string, len(columns)),
+		indexes: map[string]int{},
+	}
+	for index, name := range columns {
+		h.names[index] = strings.TrimSpace(name)
+		normalized := NormalizeColumnName(name)
+		if _, exists := h.indexes[normalized]; !exists {
+			h.indexes[normalized] = index
+		}
+	}
+
+	return h
+}
+
+// Names returns the column names as written on the header.
+func (h *Header) Names() []string {
+	return h.names
+}
+
+func (h *Header) Index(name string) (int, bool) {
+	index, exists := h.indexes[NormalizeColumnName(name)]
+	return index, exists
+}
+
+func (h *Header) Has(name string) bool {
+	_, exists := h.Index(name)
+	return exists
+}
+
+// NormalizeColumnName ignores case and any hint between parenthesis,
+// e.g. "Daily Limit (hh:mm:ss)" becomes "daily limit".
+func NormalizeColumnName(name string) string {
+	if index := strings.Index(name, "("); index >= 0 {
+		name = name[:index]
+	}
+
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// Record is a row along with the header of its table.
+type Record struct {
+	Header  *Header
+	Columns []string
+}
+
+// Get returns the column by name, or empty if the header or the row doesn't have it.
+func (r Record) Get(name string) string {
+	index, exists := r.Header.Index(name)
+	if !exists || index >= len(r.Columns) {
+		return ""
+	}
+
+	return strings.TrimSpace(r.Columns[index])
+}
+
+// Extra returns the columns out of the known names, keyed by their names on the header.
+func (r Record) Extra(known ...string) map[string]string {
+	isKnown := map[string]bool{}
+	for _, name := range known {
+		isKnown[NormalizeColumnName(name)] = true
+	}
+
+	extra := map[string]string{}
+	for index, name := range r.Header.names {
+		if name == "" || isKnown[NormalizeColumnName(name)] || index >= len(r.Columns) {
+			continue
+		}
+
+		extra[name] = strings.TrimSpace(r.Columns[index])
+	}
+
+	return extra
+}
+
+// RecordReader reads the header of the stream at once, then the next rows as records.
+// The rows are still counted from the header, the row 0, on Seek and Mark.
+type RecordReader struct {
+	DataStream
+	header *Header
+}
+
+func NewRecordReader(ds DataStream) (*RecordReader, error) {
+	err := ds.Seek(0)
+	if err != nil {
+		return nil, err
+	}
+
+	columns, err := ds.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	return &RecordReader{DataStream: ds, header: NewHeader(columns)}, nil
+}
+
+func (rr *RecordReader) Header() *Header {
+	return rr.header
+}
+
+func (rr *RecordReader) ReadRecord() (Record, error) {
+	columns, err := rr.Read()
+	if err != nil {
+		return Record{}, err
+	}
+
+	return Record{Header: rr.header, Columns: columns}, nil
+}
@@ -0,0 +1,53 @@
+package stream_test
+
+import (
+	"testing"
+
+	"github.com/kaiquegarcia/gostudy/v2/stream"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RecordReader(t *testing.T) {
+	rows := [][]string{
+		{"Title", "Notes", "Duration (format hh:mm:ss)", "Subject"},
+		{"Sets", "read twice", "00:30:00", "Logic"},
+		{"Induction", "", "00:45:00"},
+	}
+
+	t.Run("should find the columns by name, ignoring case and hints", func(t *testing.T) {
+		// Arrange
+		rr, err := stream.NewRecordReader(stream.NewMemoryDataStream(rows))
+		if !assert.Nil(t, err, "err from NewRecordReader should be nil") {
+			t.FailNow()
+		}
+
+		// Act
+		record, err := rr.ReadRecord()
+
+		// Assert
+		assert.Nil(t, err, "err from ReadRecord should be nil")
+		assert.Equal(t, "00:30:00", record.Get("duration"))
+		assert.Equal(t, "Logic", record.Get("Subject (repeatable)"))
+		assert.Equal(t, "", record.Get("Reference"), "missing columns should be empty")
+		assert.Equal(t, map[string]string{"Notes": "read twice"}, record.Extra("Title", "Duration", "Subject"))
+	})
+
+	t.Run("should read short rows and keep counting rows from the header", func(t *testing.T) {
+		// Arrange
+		rr, err := stream.NewRecordReader(stream.NewMemoryDataStream(rows))
+		if !assert.Nil(t, err, "err from NewRecordReader should be nil") {
+			t.FailNow()
+		}
+
+		// Act
+		rr.ReadRecord()
+		record, err := rr.ReadRecord()
+		mark := rr.Mark()
+
+		// Assert
+		assert.Nil(t, err, "err from ReadRecord should be nil")
+		assert.Equal(t, "", record.Get("Subject"), "columns beyond the row should be empty")
+		assert.Equal(t, 3, mark.Row(), "the second record should be followed by row 3")
+		assert.Equal(t, []string{"Title", "Notes", "Duration (format hh:mm:ss)", "Subject"}, rr.Header().Names())
+	})
+}
@@ -0,0 +1,8 @@
+package utils
+
+import "os"
+
+func FileExists(filename string) bool {
+	info, err := os.Stat(filename)
+	return err == nil && !info.IsDir()
+}
@@ -0,0 +1,11 @@
+package utils
+
+type OptionalFilenames struct {
+	Blackouts          string `example:"blackouts.csv"`
+	BlackoutsCalendar  string `example:"blackouts.ics"`
+	HourGradeOverrides string `example:"hour_grade_overrides.csv"`
+	// ProposedDisciplines is written by the estimate command
+	ProposedDisciplines string `example:"disciplines.proposed.csv"`
+	// Progress is required by the replan command
+	Progress string `example:"progress.csv"`
+}
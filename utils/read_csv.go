@@ -1,18 +1,35 @@
 package utils
 
 import (
+	"bytes"
 	"encoding/csv"
 	"os"
+
+	"github.com/kaiquegarcia/gostudy/v2/stream"
 )
 
-func ReadCSV(filename string) ([][]string, error) {
-	file, err := os.OpenFile(filename, os.O_RDONLY, os.ModePerm)
+// ReadCSV reads the whole file, skipping the BOM and using the delimiter,
+// detected from the header if it's stream.AutoDelimiter. The line breaks may
+// be \n, \r\n or \r.
+func ReadCSV(filename string, delimiter byte) ([][]string, error) {
+	err := stream.CheckDelimiter(delimiter)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filename)
 	if err != nil {
 		return nil, err
 	}
 
-	defer file.Close()
+	// encoding/csv already reads \r\n, but not the \r alone
+	data = bytes.ReplaceAll(stream.StripBOM(data), []byte("\r\n"), []byte("\n"))
+	data = bytes.ReplaceAll(data, []byte("\r"), []byte("\n"))
+	if delimiter == stream.AutoDelimiter {
+		delimiter = stream.DetectDelimiter(data)
+	}
 
-	cr := csv.NewReader(file)
+	cr := csv.NewReader(bytes.NewReader(data))
+	cr.Comma = rune(delimiter)
 	return cr.ReadAll()
 }